@@ -0,0 +1,76 @@
+package litxap
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// alignSyllables finds the byte range in s for each syllable, ignoring case. The syllables do not need to
+// add up to s, so it can be used to find where syllables changed by filters were in the original text. Any
+// letter of a syllable that is not in s is skipped, and a syllable with nothing left gets an empty range.
+func alignSyllables(s string, syllables []string) [][2]int {
+	a := []rune(s)
+	b := make([]rune, 0, len(s))
+	syllableIndices := make([]int, 0, len(s))
+	for i, syllable := range syllables {
+		for _, r := range syllable {
+			b = append(b, r)
+			syllableIndices = append(syllableIndices, i)
+		}
+	}
+
+	// Longest common subsequence, backwards so it can be read out forwards.
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if unicode.ToLower(a[i]) == unicode.ToLower(b[j]) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	// Byte offsets of every rune in s.
+	offsets := make([]int, len(a)+1)
+	for i, r := range a {
+		offsets[i+1] = offsets[i] + utf8.RuneLen(r)
+	}
+
+	spans := make([][2]int, len(syllables))
+	for k := range spans {
+		spans[k] = [2]int{-1, -1}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if unicode.ToLower(a[i]) == unicode.ToLower(b[j]) {
+			span := &spans[syllableIndices[j]]
+			if span[0] == -1 {
+				span[0] = offsets[i]
+			}
+			span[1] = offsets[i+1]
+			i++
+			j++
+		} else if lengths[i+1][j] >= lengths[i][j+1] {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	// Give the unmatched syllables an empty range where they would have been.
+	pos := 0
+	for k := range spans {
+		if spans[k][0] == -1 {
+			spans[k] = [2]int{pos, pos}
+		} else {
+			pos = spans[k][1]
+		}
+	}
+
+	return spans
+}
@@ -0,0 +1,31 @@
+package litxap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlignSyllables(t *testing.T) {
+	table := []struct {
+		input     string
+		syllables []string
+		expected  [][2]int
+	}{
+		{"Kaltxì", []string{"Kal", "txì"}, [][2]int{{0, 3}, {3, 7}}},
+		{"Kaltxì", []string{"kal", "tì"}, [][2]int{{0, 3}, {3, 7}}},
+		{"Kiyevame", []string{"Ki", "ye", "va"}, [][2]int{{0, 2}, {2, 4}, {4, 6}}},
+		{"ulte", []string{"mul", "te"}, [][2]int{{0, 2}, {2, 4}}},
+		{"Eywa", []string{"tEy", "wa"}, [][2]int{{0, 2}, {2, 4}}},
+		{"RUMAUT", []string{"Ru", "maWt"}, [][2]int{{0, 2}, {2, 6}}},
+		{"Sänume", []string{"Snu", "me"}, [][2]int{{0, 5}, {5, 7}}},
+		{"fmeretok", []string{"re", "tok"}, [][2]int{{3, 5}, {5, 8}}},
+		{"tìng", []string{"x", "tì"}, [][2]int{{0, 0}, {0, 3}}},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			assert.Equal(t, row.expected, alignSyllables(row.input, row.syllables))
+		})
+	}
+}
@@ -3,10 +3,14 @@ package litxap
 import (
 	"fmt"
 	"strings"
+
+	"github.com/gissleh/litxap/litxaputil"
 )
 
 // CustomWords generates a dictionary for custom words like names and so on. It has a hardcoded set of
-// adpositions, so that must be changed if we get a new one. This does not enforce suffix rules.
+// adpositions, so that must be changed if we get a new one. This does not enforce suffix rules. Names that
+// cannot be Na'vi words are left out, see litxaputil.ValidateWord; irregular clusters like in tlalim are allowed.
+// The entries are proper nouns.
 func CustomWords(names []string, definition string) Dictionary {
 	namesMap := make(map[string]string, len(names))
 	for _, name := range names {
@@ -31,6 +35,10 @@ func CustomWordsWithIDs(names map[string]string, definition string) Dictionary {
 
 		name = strings.ReplaceAll(strings.ToLower(name), "-", ".")
 		key := strings.ReplaceAll(strings.ReplaceAll(name, "*", ""), ".", "")
+		if litxaputil.HasPhonotacticFailures(litxaputil.ValidateWordWithMode(key, litxaputil.PhonotacticAllowIrregulars)) {
+			continue
+		}
+
 		idStr := ""
 		if id != "" {
 			idStr = fmt.Sprintf("$id:%s", id)
@@ -83,6 +91,7 @@ func (n *customWordDictionary) LookupEntries(word string) ([]Entry, error) {
 	entries := make([]Entry, len(entryStrs))
 	for i, entryStr := range entryStrs {
 		entries[i] = *ParseEntry(entryStr + ": " + n.definition)
+		entries[i].PartOfSpeech = litxaputil.PoSProperNoun
 	}
 
 	return entries, nil
@@ -18,38 +18,38 @@ func TestCustomWords(t *testing.T) {
 	entries, err := res.LookupEntries("nor")
 	assert.NoError(t, err)
 	assert.Equal(t, []Entry{
-		*ParseEntry("no: -r: Custom Word/Name"),
-		*ParseEntry("nor: : Custom Word/Name"),
+		*ParseEntry("no: -r $pos:n:pr.: Custom Word/Name"),
+		*ParseEntry("nor: $pos:n:pr.: Custom Word/Name"),
 	}, entries)
 
 	entries, err = res.LookupEntries("tamul")
 	assert.NoError(t, err)
 	assert.Equal(t, []Entry{
-		*ParseEntry("ta.*mu: -l: Custom Word/Name"),
+		*ParseEntry("ta.*mu: -l $pos:n:pr.: Custom Word/Name"),
 	}, entries)
 
 	entries, err = res.LookupEntries("kelnur")
 	assert.NoError(t, err)
 	assert.Equal(t, []Entry{
-		*ParseEntry("kel.nì: -ur: Custom Word/Name"),
+		*ParseEntry("kel.nì: -ur $pos:n:pr.: Custom Word/Name"),
 	}, entries)
 
 	entries, err = res.LookupEntries("Kelnìl")
 	assert.NoError(t, err)
 	assert.Equal(t, []Entry{
-		*ParseEntry("kel.nì: -l: Custom Word/Name"),
-		*ParseEntry("kel.nì: -ìl: Custom Word/Name"),
+		*ParseEntry("kel.nì: -l $pos:n:pr.: Custom Word/Name"),
+		*ParseEntry("kel.nì: -ìl $pos:n:pr.: Custom Word/Name"),
 	}, entries)
 
 	entries, err = res.LookupEntries("keafkxarateri")
 	assert.NoError(t, err)
 	assert.Equal(t, []Entry{
-		*ParseEntry("ke.a.fkxa.ra: -teri no_stress: Custom Word/Name"),
+		*ParseEntry("ke.a.fkxa.ra: -teri no_stress $pos:n:pr.: Custom Word/Name"),
 	}, entries)
 
 	entries, err = res.LookupEntries("telisit")
 	assert.Equal(t, []Entry{
-		*ParseEntry("te.li.si: -t: Custom Word/Name"),
+		*ParseEntry("te.li.si: -t $pos:n:pr.: Custom Word/Name"),
 	}, entries)
 
 	entries, err = res.LookupEntries("neytiriti")
@@ -57,6 +57,22 @@ func TestCustomWords(t *testing.T) {
 	assert.Nil(t, entries)
 }
 
+func TestCustomWords_Impossible(t *testing.T) {
+	res := CustomWords([]string{"tla.*lim", "*jake", "svan", "kel.nì"}, "")
+
+	entries, err := res.LookupEntries("tlalimìl")
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{*ParseEntry("tla.*lim: -ìl $pos:n:pr.: Custom Word/Name")}, entries)
+
+	for _, word := range []string{"jake", "jaket", "svan", "svanur"} {
+		_, err = res.LookupEntries(word)
+		assert.ErrorIs(t, err, ErrEntryNotFound)
+	}
+
+	_, err = res.LookupEntries("kelnì")
+	assert.NoError(t, err)
+}
+
 func TestCustomWords_WithIDs(t *testing.T) {
 	res := CustomWordsWithIDs(map[string]string{
 		"nor":    "1",
@@ -15,10 +15,14 @@ type Entry struct {
 	Word string `json:"word"`
 	// A Translation in the language, mostly to guide the user if multiple matches fit.
 	Translation string `json:"translation"`
+	// PartOfSpeech is the part of speech of the root word, if the dictionary knows it.
+	PartOfSpeech litxaputil.PartOfSpeech `json:"partOfSpeech,omitempty"`
 	// Syllables is a list of syllables
 	Syllables []string `json:"syllables"`
 	// Stress is the zero-based index of the stressed syllable.
 	Stress int `json:"stress"`
+	// SecondaryStress has the zero-based indices of syllables with a secondary stress, if the dictionary knows them.
+	SecondaryStress []int `json:"secondaryStress,omitempty"`
 	// InfixPos has a pair of positions, syllable, byte.
 	InfixPos *[2][2]int `json:"infixPos,omitempty"`
 
@@ -28,14 +32,31 @@ type Entry struct {
 	Infixes []string `json:"infixes,omitempty"`
 	// Suffixes are an in-order list of suffixes
 	Suffixes []string `json:"suffixes,omitempty"`
+
+	// Guessed is set for entries made up by GuessDictionary, which are not from a real dictionary.
+	Guessed bool `json:"guessed,omitempty"`
 }
 
 func (entry *Entry) GenerateSyllables() ([]string, int, int) {
+	syllables, stress, root, _ := entry.GenerateSyllablesWithSecondaryStress()
+	return syllables, stress, root
+}
+
+// GenerateSyllablesWithSecondaryStress is GenerateSyllables, but it also gives the secondary stresses. These are the ones
+// in SecondaryStress and on the stressed syllable of adpositions that are also their own words. They're left out
+// if they're next to another stress.
+func (entry *Entry) GenerateSyllablesWithSecondaryStress() ([]string, int, int, []int) {
 	syllables := append(entry.Syllables[:0:0], entry.Syllables...)
 	stress := entry.Stress
+	secondaryStress := slices.Clone(entry.SecondaryStress)
 
 	// Special case: oe becomes a syllable pronounced "we" when there are suffixes
 	if len(entry.Suffixes) > 0 {
+		switch entry.Word {
+		case "oe", "ayoe", "oeng", "ayoeng":
+			secondaryStress = nil
+		}
+
 		switch entry.Word {
 		case "oe":
 			syllables = []string{"oe"}
@@ -60,6 +81,9 @@ func (entry *Entry) GenerateSyllables() ([]string, int, int) {
 	if stress != -1 {
 		stress += offset
 	}
+	for i := range secondaryStress {
+		secondaryStress[i] += offset
+	}
 
 	// Apply infixes
 	if entry.InfixPos != nil && len(entry.Infixes) > 0 {
@@ -67,14 +91,24 @@ func (entry *Entry) GenerateSyllables() ([]string, int, int) {
 		positions[0][0] += offset
 		positions[1][0] += offset
 
+		// The secondary stresses are moved the same way as the stress.
+		for i, index := range secondaryStress {
+			_, secondaryStress[i] = litxaputil.ApplyInfixes(slices.Clone(syllables), entry.Infixes, index, positions)
+		}
+
 		syllables, stress = litxaputil.ApplyInfixes(syllables, entry.Infixes, stress, positions)
 	}
 
 	// Apply suffixes
-	isVerb := entry.InfixPos != nil && !slices.Contains(entry.Prefixes, "tì") && !slices.Contains(entry.Infixes, "us")
-	syllables = litxaputil.ApplySuffixes(syllables, entry.Suffixes, isVerb)
+	isVerb := entry.InfixPos != nil
+	if entry.PartOfSpeech != "" {
+		isVerb = entry.PartOfSpeech.IsVerb()
+	}
+	isVerb = isVerb && !slices.Contains(entry.Prefixes, "tì") && !slices.Contains(entry.Infixes, "us")
+	syllables, suffixSecondaryStress := litxaputil.ApplySuffixesWithStress(syllables, entry.Suffixes, isVerb)
+	secondaryStress = append(secondaryStress, suffixSecondaryStress...)
 
-	return syllables, stress, offset
+	return syllables, stress, offset, litxaputil.SecondaryStressAwayFrom(secondaryStress, stress)
 }
 
 func (entry *Entry) String() string {
@@ -88,6 +122,9 @@ func (entry *Entry) String() string {
 		if entry.Stress == i && i != 0 {
 			sb.WriteRune('*')
 		}
+		if slices.Contains(entry.SecondaryStress, i) {
+			sb.WriteString("ˌ")
+		}
 
 		foundInfix := false
 		if entry.InfixPos != nil {
@@ -109,7 +146,8 @@ func (entry *Entry) String() string {
 		}
 	}
 
-	inflected := entry.Stress == -1 || len(entry.Prefixes) > 0 || len(entry.Suffixes) > 0 || len(entry.Infixes) > 0
+	inflected := entry.Stress == -1 || entry.Guessed || entry.PartOfSpeech != "" ||
+		len(entry.Prefixes) > 0 || len(entry.Suffixes) > 0 || len(entry.Infixes) > 0
 	if inflected {
 		sb.WriteByte(':')
 	}
@@ -146,10 +184,19 @@ func (entry *Entry) String() string {
 		sb.WriteString(entry.ID)
 	}
 
+	if entry.PartOfSpeech != "" {
+		sb.WriteString(" $pos:")
+		sb.WriteString(string(entry.PartOfSpeech))
+	}
+
 	if entry.Stress == -1 {
 		sb.WriteString(" no_stress")
 	}
 
+	if entry.Guessed {
+		sb.WriteString(" guessed")
+	}
+
 	if len(entry.Translation) > 0 {
 		if !inflected {
 			sb.WriteString(": ")
@@ -173,6 +220,10 @@ func ParseEntry(s string) *Entry {
 			entry.Stress = i
 			syllable = syllable[len("*"):]
 		}
+		if strings.HasPrefix(syllable, "ˌ") {
+			entry.SecondaryStress = append(entry.SecondaryStress, i)
+			syllable = syllable[len("ˌ"):]
+		}
 
 		dotIndex := strings.Index(syllable, "·")
 		lastDotIndex := strings.LastIndex(syllable, ".")
@@ -209,9 +260,15 @@ func ParseEntry(s string) *Entry {
 			if strings.HasPrefix(token, "$id:") {
 				entry.ID = token[len("$id:"):]
 			}
+			if strings.HasPrefix(token, "$pos:") {
+				entry.PartOfSpeech = litxaputil.PartOfSpeech(token[len("$pos:"):])
+			}
 			if token == "no_stress" {
 				entry.Stress = -1
 			}
+			if token == "guessed" {
+				entry.Guessed = true
+			}
 		}
 	}
 
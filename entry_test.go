@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/gissleh/litxap/litxaputil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -53,6 +54,10 @@ func TestParseEntry(t *testing.T) {
 		"ka: <äm>: Go",
 		"s··a: <ol,ei> $id:fwew_10864: rise to a challenge",
 		"tsa.heyl: $id:fwew_3912 no_stress: (part of tsaheyl si)",
+		"mrr.vo.zam.ˌme.vol: : Number °5020 (2576)",
+		"kel.nì: guessed: Guessed",
+		"ta.ron: $pos:vtr.: hunt",
+		"Ney.*ti.ri: -ti $id:fwew_9999 $pos:n:pr.: Neytiri",
 	}
 
 	for _, row := range table {
@@ -102,6 +107,10 @@ func TestMultiDictionary_LookupEntries(t *testing.T) {
 	res, err = mdGood.LookupEntries("sa'nokur")
 	assert.NoError(t, err)
 	assert.Equal(t, res, []Entry{*ParseEntry("sa'.nok: -ur: nother")})
+
+	res, err = MultiDictionary{&NumberDictionary{}, mdGood}.LookupEntries("mevol")
+	assert.NoError(t, err)
+	assert.Equal(t, litxaputil.PoSNumber, res[0].PartOfSpeech)
 }
 
 func TestEntry_GenerateSyllables(t *testing.T) {
@@ -125,6 +134,10 @@ func TestEntry_GenerateSyllables(t *testing.T) {
 		{"·i.*n·an: <äp,eyk,us>", "ä.pey.ku.si.nan", 3, 0},
 		{"te.li.*si: -t", "te.li.sit", 2, 0},
 		{"pxo.*eng: -ru", "pxo.eng.ru", 1, 0},
+		{"u.*van.si: -tswo", "u.van.si.tswo", 1, 0},
+		{"u.*van.si: -tswo $pos:vin.", "u.van.tswo", 1, 0},
+		{"te.li.*si: -tswo $pos:n.", "te.li.si.tswo", 2, 0},
+		{"s··i: -tswo $pos:n.", "si.tswo", 0, 0},
 	}
 
 	for _, row := range table {
@@ -142,3 +155,36 @@ func TestEntry_GenerateSyllables(t *testing.T) {
 		})
 	}
 }
+
+func TestEntry_GenerateSyllablesWithSecondaryStress(t *testing.T) {
+	table := []struct {
+		Entry           string
+		Syllables       string
+		Stress          int
+		SecondaryStress []int
+	}{
+		{"fme.tok", "fme.tok", 0, nil},
+		{"fme.tok: -mungwrr", "fme.tok.mung.wrr", 0, []int{2}},
+		{"fme.tok: ay- -teri", "ay.fme.tok.te.ri", 1, []int{3}},
+		{"tì.*lam: -teri", "tì.lam.te.ri", 1, nil},
+		{"ˌmrr.vo.*mrr: a-", "a.mrr.vo.mrr", 3, []int{1}},
+		{"ˌmrr.vo.*mrr: -ìl", "mrr.vo.mrr.ìl", 2, []int{0}},
+		{"t·ì.*r·an: <ol> -mìkam", "to.lì.ran.mì.kam", 2, []int{4}},
+		{"ˌmrr.vo.zam.*me.vol", "mrr.vo.zam.me.vol", 3, []int{0}},
+	}
+
+	for _, row := range table {
+		t.Run(row.Entry, func(t *testing.T) {
+			entry := ParseEntry(row.Entry)
+			if !assert.NotNil(t, entry) {
+				return
+			}
+
+			syllables, stress, _, secondaryStress := entry.GenerateSyllablesWithSecondaryStress()
+
+			assert.Equal(t, row.Syllables, strings.Join(syllables, "."))
+			assert.Equal(t, row.Stress, stress)
+			assert.Equal(t, row.SecondaryStress, secondaryStress)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package litxap
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gissleh/litxap/litxaputil"
+)
+
+// GuessDictionary guesses the syllables and stress of words that are not found in the Dictionary. The word is split
+// with litxaputil.SplitSyllables, so words that aren't possible in Na'vi are not guessed. The entries have Guessed
+// set, so they can be told apart from the real ones. It does not know of any affixes, so it's best used for names
+// and new words.
+type GuessDictionary struct {
+	// Dictionary is looked up first, and the word is only guessed if it has no entries. Leave it nil to always guess.
+	Dictionary Dictionary
+	// Stress is the heuristic used to guess the stressed syllable.
+	Stress GuessStress
+	// AvoidLoanWordEnding keeps the stress off a final -ì after a consonant, which is added to loanwords, by
+	// counting the syllables from the one before it.
+	AvoidLoanWordEnding bool
+	// Translation is used for the entries. It defaults to "Guessed".
+	Translation string
+}
+
+// GuessStress is a heuristic for GuessDictionary.
+type GuessStress int
+
+const (
+	// GuessPenultimate stresses the second to last syllable, which is the most common in Na'vi.
+	GuessPenultimate GuessStress = iota
+	// GuessFirstSyllable stresses the first syllable.
+	GuessFirstSyllable
+	// GuessBoth makes an entry for each of the above, which leaves the word ambiguous if they disagree.
+	GuessBoth
+)
+
+func (d *GuessDictionary) LookupEntries(word string) ([]Entry, error) {
+	if d.Dictionary != nil {
+		entries, err := d.Dictionary.LookupEntries(word)
+		if err == nil || !errors.Is(err, ErrEntryNotFound) {
+			return entries, err
+		}
+	}
+
+	split := litxaputil.SplitSyllables(word)
+	if len(split) == 0 {
+		return nil, ErrEntryNotFound
+	}
+
+	syllables := make([]string, len(split))
+	for i, syllable := range split {
+		if syllable.NewWord {
+			return nil, ErrEntryNotFound
+		}
+
+		syllables[i] = syllable.String()
+	}
+
+	translation := d.Translation
+	if translation == "" {
+		translation = "Guessed"
+	}
+
+	var stresses []int
+	switch d.Stress {
+	case GuessPenultimate:
+		stresses = []int{d.penultimate(split)}
+	case GuessFirstSyllable:
+		stresses = []int{0}
+	case GuessBoth:
+		stresses = []int{d.penultimate(split)}
+		if stresses[0] != 0 {
+			stresses = append(stresses, 0)
+		}
+	}
+
+	entries := make([]Entry, 0, len(stresses))
+	for _, stress := range stresses {
+		entries = append(entries, Entry{
+			Word:        strings.Join(syllables, ""),
+			Translation: translation,
+			Syllables:   syllables,
+			Stress:      stress,
+			Guessed:     true,
+		})
+	}
+
+	return entries, nil
+}
+
+func (d *GuessDictionary) penultimate(syllables litxaputil.Syllables) int {
+	count := len(syllables)
+	if last := syllables[count-1]; d.AvoidLoanWordEnding && count > 1 && last.Body == "ì" && last.Coda == "" && last.Onset != "" {
+		count -= 1
+	}
+
+	return max(count-2, 0)
+}
@@ -0,0 +1,59 @@
+package litxap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuessDictionary_LookupEntries(t *testing.T) {
+	table := []struct {
+		name     string
+		dict     GuessDictionary
+		word     string
+		expected []Entry
+	}{
+		{"Penultimate", GuessDictionary{}, "neytiri", []Entry{*ParseEntry("ney.*ti.ri: guessed: Guessed")}},
+		{"FirstSyllable", GuessDictionary{Stress: GuessFirstSyllable}, "neytiri", []Entry{*ParseEntry("ney.ti.ri: guessed: Guessed")}},
+		{"Both", GuessDictionary{Stress: GuessBoth}, "neytiri", []Entry{
+			*ParseEntry("ney.*ti.ri: guessed: Guessed"),
+			*ParseEntry("ney.ti.ri: guessed: Guessed"),
+		}},
+		{"BothAgree", GuessDictionary{Stress: GuessBoth}, "tsutey", []Entry{*ParseEntry("tsu.tey: guessed: Guessed")}},
+		{"OneSyllable", GuessDictionary{}, "tsu", []Entry{*ParseEntry("tsu: guessed: Guessed")}},
+		{"LoanWord", GuessDictionary{}, "amerikì", []Entry{*ParseEntry("a.me.*ri.kì: guessed: Guessed")}},
+		{"AvoidLoanWordEnding", GuessDictionary{AvoidLoanWordEnding: true}, "amerikì", []Entry{*ParseEntry("a.*me.ri.kì: guessed: Guessed")}},
+		{"Translation", GuessDictionary{Translation: "Name"}, "tlalim", []Entry{*ParseEntry("tla.lim: guessed: Name")}},
+		{"Found", GuessDictionary{Dictionary: dummyDictionary}, "ngati", []Entry{dummyDictionary["ngati"]}},
+		{"NotFound", GuessDictionary{Dictionary: dummyDictionary}, "neytiri", []Entry{*ParseEntry("ney.*ti.ri: guessed: Guessed")}},
+		{"Impossible", GuessDictionary{}, "svane", nil},
+		{"MultiWord", GuessDictionary{}, "uvan si", nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			res, err := row.dict.LookupEntries(row.word)
+			if row.expected == nil {
+				assert.ErrorIs(t, err, ErrEntryNotFound)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, row.expected, res)
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := (&GuessDictionary{Dictionary: BrokenDictionary{}}).LookupEntries("neytiri")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrEntryNotFound)
+	})
+}
+
+func TestGuessDictionary_RunLine(t *testing.T) {
+	line, err := RunLine("Kaltxì, Neytiri!", &GuessDictionary{Dictionary: dummyDictionary})
+	assert.NoError(t, err)
+	assert.False(t, line[0].Matches[0].Entry.Guessed)
+	assert.True(t, line[2].Matches[0].Entry.Guessed)
+	assert.Equal(t, []string{"Ney", "ti", "ri"}, line[2].Matches[0].Syllables)
+	assert.Equal(t, 1, line[2].Matches[0].Stress)
+}
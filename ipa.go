@@ -0,0 +1,262 @@
+package litxap
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gissleh/litxap/litxaputil"
+)
+
+// IPAOptions decides how Line.IPAWithOptions transcribes the line. The zero value gives the same as Line.IPA
+// without a syllable delimiter.
+type IPAOptions struct {
+	// SyllableDelimiter is put between the syllables of a word, e.g. "." for a dictionary-like transcription.
+	SyllableDelimiter string
+	// WordSeparator, if set, replaces the text between words. The punctuation and spaces before the first
+	// word and after the last word are left out.
+	WordSeparator string
+	// StressMonosyllables marks the stress on words with one syllable as well.
+	StressMonosyllables bool
+	// Fallback transcribes words without matches by splitting them with litxaputil.SplitSyllables. Their
+	// stress is not known, so it is left unmarked.
+	Fallback bool
+	// Transcription picks how narrow the transcription is.
+	Transcription IPATranscription
+	// Reef voices the ejectives like the Reef dialect in the phonetic transcription.
+	Reef bool
+}
+
+// IPATranscription decides which sounds are written in the IPA.
+type IPATranscription int
+
+const (
+	// IPADictionary is the transcription used by the dictionaries, which marks the unreleased stops.
+	IPADictionary IPATranscription = iota
+	// IPAPhonemic leaves out the allophones, like the unreleased stops.
+	IPAPhonemic
+	// IPAPhonetic writes the allophones, also across word boundaries within a clause. See
+	// litxaputil.WriteSyllablesAsPhoneticIPATo for the rules.
+	IPAPhonetic
+)
+
+// LineIPA has the IPA for each part of a line.
+type LineIPA []LinePartIPA
+
+// LinePartIPA is the transcription of one LinePart. For words that could not be transcribed, the IPA is
+// empty and Err is set.
+type LinePartIPA struct {
+	Raw    string
+	IPA    string
+	IsWord bool
+	// Ambiguous is set if the matches disagreed on stress, and the first match was used.
+	Ambiguous bool
+	// Guessed is set if the word had no matches, and it was transcribed by IPAOptions.Fallback.
+	Guessed bool
+	Err     error
+}
+
+// String joins the IPA of the parts, using the raw text for the words that failed.
+func (l LineIPA) String() string {
+	sb := &strings.Builder{}
+	for _, part := range l {
+		if part.Err != nil {
+			sb.WriteString(part.Raw)
+		} else {
+			sb.WriteString(part.IPA)
+		}
+	}
+
+	return sb.String()
+}
+
+// Err returns the first error in the line, if any.
+func (l LineIPA) Err() error {
+	for _, part := range l {
+		if part.Err != nil {
+			return part.Err
+		}
+	}
+
+	return nil
+}
+
+// IPA transcribes the part on its own with Line.IPAWithOptions, using the selected match. Since the words around it
+// are not known, the phonetic transcription won't have the changes across word boundaries.
+func (part LinePart) IPA(selection int, opts IPAOptions) LinePartIPA {
+	return Line{part}.IPAWithOptions(map[int]int{0: selection}, opts)[0]
+}
+
+// IPAWithOptions transcribes each part of the line. Unlike IPA, a word that fails to transcribe will not stop the
+// rest of the line from being transcribed, so the failures can be shown in the context of the line.
+func (line Line) IPAWithOptions(selections map[int]int, opts IPAOptions) LineIPA {
+	res := make(LineIPA, len(line))
+	words := make([]ipaWord, len(line))
+
+	first, last := -1, -1
+	for i, part := range line {
+		res[i] = LinePartIPA{Raw: part.Raw, IsWord: part.IsWord}
+		if part.IsWord {
+			if first == -1 {
+				first = i
+			}
+			last = i
+
+			words[i], res[i].Err = line.ipaWord(i, selections, opts)
+			res[i].Ambiguous = words[i].ambiguous
+			res[i].Guessed = words[i].guessed
+		}
+	}
+
+	for i, part := range line {
+		if !part.IsWord {
+			switch {
+			case opts.WordSeparator == "":
+				res[i].IPA = part.Raw
+			case i > first && i < last:
+				res[i].IPA = opts.WordSeparator
+			}
+
+			continue
+		}
+		if res[i].Err != nil {
+			continue
+		}
+
+		var ipa string
+		var err error
+		word := words[i]
+		if opts.Transcription == IPAPhonetic {
+			var prev, next string
+			if j := line.ipaNeighbor(i, -1); j != -1 && len(words[j].syllables) > 0 {
+				prev = words[j].syllables[len(words[j].syllables)-1]
+			}
+			if j := line.ipaNeighbor(i, 1); j != -1 && len(words[j].syllables) > 0 {
+				next = words[j].syllables[0]
+			}
+
+			ipa, err = litxaputil.SyllablesToPhoneticIPA(word.syllables, opts.SyllableDelimiter, []int{word.stress},
+				word.secondaryStress, prev, next, litxaputil.PhoneticOptions{Reef: opts.Reef})
+		} else {
+			ipa, err = litxaputil.SyllablesToIPA(word.syllables, opts.SyllableDelimiter, []int{word.stress}, word.secondaryStress)
+		}
+		if err != nil {
+			res[i].Err = err
+			continue
+		}
+		if opts.Transcription == IPAPhonemic {
+			ipa = strings.ReplaceAll(ipa, litxaputil.IPAUnreleased, "")
+		}
+
+		res[i].IPA = ipa
+	}
+
+	return res
+}
+
+type ipaWord struct {
+	syllables []string
+	// text is the syllables with their casing, for when they are written out.
+	text            []string
+	stress          int
+	secondaryStress []int
+	ambiguous       bool
+	guessed         bool
+}
+
+// ipaWord gets the lowercase syllables and stress to transcribe for the word at the index.
+func (line Line) ipaWord(i int, selections map[int]int, opts IPAOptions) (ipaWord, error) {
+	part := &line[i]
+	word := ipaWord{}
+
+	selected, ok := selections[i]
+	if !ok {
+		selected = -1
+	}
+
+	match, stress := part.SelectedMatch(selected)
+	switch stress {
+	case LPSNoMatches:
+		if !opts.Fallback {
+			return word, fmt.Errorf("no matches for line[%d] (%#+v)", i, part.Raw)
+		}
+
+		split := litxaputil.SplitSyllables(part.Raw)
+		if split == nil {
+			return word, fmt.Errorf("%w: line[%d] (%#+v)", ErrCannotSplitSyllables, i, part.Raw)
+		}
+
+		for _, syllable := range split {
+			word.syllables = append(word.syllables, syllable.PreOnset+syllable.Onset+syllable.Irregular+syllable.Body+syllable.Coda)
+		}
+		word.guessed = true
+		stress = -1
+	case LPSAmbiguousMatches:
+		match = &part.Matches[0]
+		stress = match.Stress
+		word.ambiguous = true
+	case LPSAnyStress:
+		stress = -1
+	}
+	if match != nil {
+		word.syllables = slices.Clone(match.Syllables)
+		word.secondaryStress = match.SecondaryStress
+	}
+
+	word.text = slices.Clone(word.syllables)
+	for j := range word.syllables {
+		word.syllables[j] = strings.ToLower(word.syllables[j])
+	}
+	if len(word.syllables) == 1 && !opts.StressMonosyllables {
+		stress = -1
+	}
+	word.stress = stress
+
+	return word, nil
+}
+
+// ipaNeighbor finds the closest word in the direction, unless there's punctuation other than a comma in the way.
+// It returns -1 if there's none.
+func (line Line) ipaNeighbor(i, direction int) int {
+	between := ""
+	for j := i + direction; j >= 0 && j < len(line); j += direction {
+		if line[j].IsWord {
+			if !litxaputil.ContinuesClause(between) {
+				return -1
+			}
+
+			return j
+		}
+
+		between += line[j].Raw
+	}
+
+	return -1
+}
+
+var ErrCannotSplitSyllables = errors.New("cannot split into syllables")
+
+// ParseIPALine turns an IPA line, like the one made by IPA, back into a Line spelled in the Na'vi alphabet. Each
+// word gets one match per alternative pronunciation, with the syllables and stress from the IPA but no entry;
+// run the line through a dictionary to find them. Unknown symbols are left out, and an
+// *litxaputil.UnknownIPASymbolsError with their positions is returned along with the line.
+func ParseIPALine(ipa string) (Line, error) {
+	parts, err := litxaputil.SpellIPA(ipa)
+
+	res := make(Line, 0, len(parts))
+	for _, part := range parts {
+		linePart := LinePart{Raw: part.Raw, IsWord: part.IsWord}
+		for _, alternative := range part.Alternatives {
+			linePart.Matches = append(linePart.Matches, LinePartMatch{
+				Syllables:       alternative.Syllables,
+				Stress:          alternative.Stress,
+				SecondaryStress: alternative.SecondaryStress,
+			})
+		}
+
+		res = append(res, linePart)
+	}
+
+	return res, err
+}
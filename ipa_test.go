@@ -0,0 +1,126 @@
+package litxap
+
+import (
+	"testing"
+
+	"github.com/gissleh/litxap/litxaputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLine_IPAWithOptions(t *testing.T) {
+	table := []struct {
+		name       string
+		input      Line
+		opts       IPAOptions
+		selections map[int]int
+		output     string
+		errIndex   int
+	}{
+		{"Default", lineKaltxiMaFmetokyu, IPAOptions{}, nil, "kalˈtʼɪ, ma ˈfmɛtok̚ju!", -1},
+		{"SyllableDelimiter", lineKaltxiMaFmetokyu, IPAOptions{SyllableDelimiter: "."}, nil, "kal.ˈtʼɪ, ma ˈfmɛ.tok̚.ju!", -1},
+		{"WordSeparator", lineKaltxiMaFmetokyu, IPAOptions{WordSeparator: " "}, nil, "kalˈtʼɪ ma ˈfmɛtok̚ju", -1},
+		{"StressMonosyllables", lineKaltxiMaFmetokyu, IPAOptions{StressMonosyllables: true}, nil, "kalˈtʼɪ, ˈma ˈfmɛtok̚ju!", -1},
+		{"Phonemic", lineKaltxiMaFmetokyu, IPAOptions{Transcription: IPAPhonemic}, nil, "kalˈtʼɪ, ma ˈfmɛtokju!", -1},
+		{"NoMatches", lineVolaSkeynven, IPAOptions{}, nil, "ˈvola skeynven.", 2},
+		{"Fallback", lineVolaSkeynven, IPAOptions{Fallback: true}, nil, "ˈvola skɛjnvɛn.", -1},
+		{"UnknownSymbols", lineFmetokBad, IPAOptions{Fallback: true}, nil, "Vola", 0},
+		{"FallbackFailed", Line{{Raw: "Xyz", IsWord: true}, {Raw: "!"}}, IPAOptions{Fallback: true}, nil, "Xyz!", 0},
+		{"Ambiguous", lineKaltxiMaFmetan, IPAOptions{}, nil, "kalˈtʼɪ, ma ˈfmɛtan!", -1},
+		{"AnyStress", lineFikemIlaFyao, IPAOptions{SyllableDelimiter: "."}, map[int]int{2: 2}, "fɪ.ˈkɛm ɪ.læ ˈfja.ʔo!", -1},
+		{"Phonetic", lineTingPxawFmetokUlteRey, IPAOptions{Transcription: IPAPhonetic}, nil, "tɪm pʼaw, ˈfmɛtok ˈultɛ ɾɛj.", -1},
+		{"PhoneticReef", lineTingPxawFmetokUlteRey, IPAOptions{Transcription: IPAPhonetic, Reef: true}, nil, "tɪm baw, ˈfmɛtok ˈultɛ ɾɛj.", -1},
+		{"PhoneticWordSeparator", lineTingPxawFmetokUlteRey, IPAOptions{Transcription: IPAPhonetic, WordSeparator: " "}, nil, "tɪm pʼaw ˈfmɛtok ˈultɛ ɾɛj", -1},
+		{"PhoneticPause", lineTingPxaw, IPAOptions{Transcription: IPAPhonetic}, nil, "tɪŋ. pʼaw!", -1},
+		{"PhoneticPauseWordSeparator", lineTingPxaw, IPAOptions{Transcription: IPAPhonetic, WordSeparator: " "}, nil, "tɪŋ pʼaw", -1},
+		{"NotPhonetic", lineTingPxawFmetokUlteRey, IPAOptions{}, nil, "tɪŋ pʼaw, ˈfmɛtok̚ ˈultɛ ɾɛj.", -1},
+		{"SecondaryStress", lineMrrvomrrFmetokmungwrr, IPAOptions{WordSeparator: " | "}, nil, "ˌmr̩voˈmr̩ | ˈfmɛtok̚ˌmuŋwr̩", -1},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			res := row.input.IPAWithOptions(row.selections, row.opts)
+			assert.Equal(t, row.output, res.String())
+
+			for i, part := range res {
+				if i == row.errIndex {
+					assert.Error(t, part.Err)
+					assert.Empty(t, part.IPA)
+				} else {
+					assert.NoError(t, part.Err)
+				}
+			}
+		})
+	}
+
+	t.Run("Flags", func(t *testing.T) {
+		res := lineKaltxiMaFmetan.IPAWithOptions(nil, IPAOptions{})
+		assert.True(t, res[4].Ambiguous)
+		assert.False(t, res[0].Ambiguous)
+
+		res = lineVolaSkeynven.IPAWithOptions(nil, IPAOptions{Fallback: true})
+		assert.True(t, res[2].Guessed)
+		assert.False(t, res[0].Guessed)
+		assert.NoError(t, res.Err())
+	})
+}
+
+var lineTingPxawFmetokUlteRey = Line{
+	{Raw: "Tìng", IsWord: true, Matches: []LinePartMatch{{Syllables: []string{"Tìng"}, Stress: 0}}},
+	{Raw: " "},
+	{Raw: "pxaw", IsWord: true, Matches: []LinePartMatch{{Syllables: []string{"pxaw"}, Stress: 0}}},
+	{Raw: ", "},
+	{Raw: "fmetok", IsWord: true, Matches: []LinePartMatch{{Syllables: []string{"fme", "tok"}, Stress: 0}}},
+	{Raw: " "},
+	{Raw: "ulte", IsWord: true, Matches: []LinePartMatch{{Syllables: []string{"ul", "te"}, Stress: 0}}},
+	{Raw: " "},
+	{Raw: "rey", IsWord: true, Matches: []LinePartMatch{{Syllables: []string{"rey"}, Stress: 0}}},
+	{Raw: "."},
+}
+
+var lineTingPxaw = Line{
+	{Raw: "Tìng", IsWord: true, Matches: []LinePartMatch{{Syllables: []string{"Tìng"}, Stress: 0}}},
+	{Raw: ". "},
+	{Raw: "Pxaw", IsWord: true, Matches: []LinePartMatch{{Syllables: []string{"Pxaw"}, Stress: 0}}},
+	{Raw: "!"},
+}
+
+func TestParseIPALine(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		for _, line := range []Line{lineKaltxiMaFmetokyu, lineTingPxawFmetokUlteRey, lineMrrvomrrFmetokmungwrr} {
+			for _, delimiter := range []string{"", "."} {
+				ipa, err := line.IPA(nil, delimiter)
+				assert.NoError(t, err)
+
+				parsed, err := ParseIPALine(ipa)
+				assert.NoError(t, err)
+
+				res, err := parsed.IPA(nil, delimiter)
+				assert.NoError(t, err)
+				assert.Equal(t, ipa, res)
+			}
+		}
+	})
+
+	t.Run("Alternatives", func(t *testing.T) {
+		line, err := ParseIPALine("[aj.ˈfo] or [ˈaj.fo]")
+		assert.NoError(t, err)
+		assert.Equal(t, Line{
+			{Raw: "["},
+			{Raw: "ayfo", IsWord: true, Matches: []LinePartMatch{
+				{Syllables: []string{"ay", "fo"}, Stress: 1},
+				{Syllables: []string{"ay", "fo"}, Stress: 0},
+			}},
+			{Raw: "]"},
+		}, line)
+	})
+
+	t.Run("UnknownSymbols", func(t *testing.T) {
+		line, err := ParseIPALine("ma ˈsköxaw")
+		assert.Equal(t, []string{"ma", " ", "skaw"}, []string{line[0].Raw, line[1].Raw, line[2].Raw})
+
+		var symbolsErr *litxaputil.UnknownIPASymbolsError
+		if assert.ErrorAs(t, err, &symbolsErr) {
+			assert.Equal(t, []litxaputil.UnknownIPASymbol{{Symbol: "ö", Position: 7}, {Symbol: "x", Position: 9}}, symbolsErr.Symbols)
+		}
+	})
+}
@@ -42,34 +42,90 @@ func (line Line) Run(dict Dictionary) (Line, error) {
 }
 
 func (line Line) Format(f LineFormatter, selections map[int]int) string {
+	return line.FormatWithMode(f, selections, FormatDefault)
+}
+
+// FormatWithMode formats the line like Format, but lets you choose what text to put the stress marks on
+// for lines that have been changed by filters.
+func (line Line) FormatWithMode(f LineFormatter, selections map[int]int, mode FormatMode) string {
 	stressOpen, stressClose := f.StressedSyllableTags()
 	sb := &strings.Builder{}
 
+	escape := func(s string) string { return s }
+	if escaper, ok := f.(LineTextEscaper); ok {
+		escape = escaper.EscapeText
+	}
+	separator := ""
+	if separatorFormatter, ok := f.(LineSyllableSeparator); ok {
+		separator = separatorFormatter.SyllableSeparator()
+	}
+	var secondaryOpen, secondaryClose string
+	if secondaryFormatter, ok := f.(LineSecondaryStressFormatter); ok {
+		secondaryOpen, secondaryClose = secondaryFormatter.SecondaryStressedSyllableTags()
+	}
+	var changedOpen, changedClose string
+	if changedFormatter, ok := f.(LineChangedSyllableFormatter); ok {
+		changedOpen, changedClose = changedFormatter.ChangedSyllableTags()
+	}
+
+	rich, isRich := f.(RichLineFormatter)
+
 	for i, part := range line {
 		selected, ok := selections[i]
 		if !ok {
 			selected = -1
 		}
 
-		syllables, stress := part.GetSyllables(selected)
-		partOpen, partClose := f.LinePartTags(part, stress)
+		match, stress := part.SelectedMatch(selected)
+
+		var partOpen, partClose string
+		if isRich && part.IsWord {
+			partOpen, partClose = rich.WordTags(part, match, stress)
+		} else {
+			partOpen, partClose = f.LinePartTags(part, stress)
+		}
 		sb.WriteString(partOpen)
-		if syllables != nil {
-			if stress >= 0 && len(syllables) > 1 {
-				for j, syllable := range syllables {
-					if j == stress {
-						sb.WriteString(stressOpen)
-						sb.WriteString(syllable)
-						sb.WriteString(stressClose)
-					} else {
-						sb.WriteString(syllable)
-					}
+
+		text, spans, plain := part.formatSpans(match, stress, mode)
+		marked := isRich || separator != "" || (stress >= 0 && len(spans) > 1) ||
+			(changedOpen != "" && match != nil && len(match.ChangedSyllables) > 0)
+		if spans != nil && marked {
+			pos := 0
+			for j, span := range spans {
+				if span[0] > pos {
+					sb.WriteString(escape(text[pos:span[0]]))
+				}
+
+				if j > 0 && separator != "" && canSeparateSyllables(match.Syllables[j-1], match.Syllables[j]) {
+					sb.WriteString(separator)
+				}
+
+				var syllableOpen, syllableClose string
+				if isRich {
+					syllableOpen, syllableClose = rich.SyllableTags(match, j, j == stress)
+				} else if j == stress && len(spans) > 1 {
+					syllableOpen, syllableClose = stressOpen, stressClose
+				} else if slices.Contains(match.SecondaryStress, j) {
+					syllableOpen, syllableClose = secondaryOpen, secondaryClose
 				}
-			} else {
-				sb.WriteString(part.Raw)
+
+				changed := slices.Contains(match.ChangedSyllables, j)
+				if changed {
+					sb.WriteString(changedOpen)
+				}
+				sb.WriteString(syllableOpen)
+				sb.WriteString(escape(text[span[0]:span[1]]))
+				sb.WriteString(syllableClose)
+				if changed {
+					sb.WriteString(changedClose)
+				}
+				pos = span[1]
+			}
+			if pos < len(text) {
+				sb.WriteString(escape(text[pos:]))
 			}
 		} else {
-			sb.WriteString(part.Raw)
+			sb.WriteString(escape(plain))
 		}
 
 		sb.WriteString(partClose)
@@ -78,41 +134,63 @@ func (line Line) Format(f LineFormatter, selections map[int]int) string {
 	return sb.String()
 }
 
-func (line Line) IPA(selections map[int]int, syllableDelimiter string) (string, error) {
-	sb := &strings.Builder{}
-	sb.Grow(len(line) * 8)
+// formatSpans gets the text to write for the part along with the byte ranges in it for each of
+// the match's syllables. The plain text is written instead if there is nothing to mark, and the spans
+// are nil if there are no syllables.
+func (part *LinePart) formatSpans(match *LinePartMatch, stress int, mode FormatMode) (string, [][2]int, string) {
+	original := part.Raw
+	if mode == FormatOriginal && part.Original != "" {
+		original = part.Original
+	}
+	if match == nil || (mode == FormatOriginal && part.IsNumber) {
+		// The digits of a number can't be lined up with the syllables.
+		return original, nil, original
+	}
 
-	for i, part := range line {
-		if part.IsWord {
-			if len(part.Matches) == 0 {
-				return "", fmt.Errorf("no matches for line[%d] (%#+v)", i, part.Raw)
-			}
+	phonetic := strings.Join(match.Syllables, "")
+	phoneticSpans := make([][2]int, len(match.Syllables))
+	pos := 0
+	for j, syllable := range match.Syllables {
+		phoneticSpans[j] = [2]int{pos, pos + len(syllable)}
+		pos += len(syllable)
+	}
 
-			selection := part.Matches[0]
-			if selections[i] != 0 && selections[i] < len(part.Matches) {
-				selection = part.Matches[selections[i]]
-			}
+	switch mode {
+	case FormatPhonetic:
+		return phonetic, phoneticSpans, phonetic
+	case FormatOriginal:
+		spans := alignSyllables(original, match.Syllables)
+		if stress >= 0 && stress < len(spans) && spans[stress][0] == spans[stress][1] {
+			// There's nothing left of the stressed syllable to mark, so this is the best we can do.
+			return phonetic, phoneticSpans, original
+		}
 
-			syllables := slices.Clone(selection.Syllables)
-			for j := range syllables {
-				syllables[j] = strings.ToLower(syllables[j])
-			}
+		return original, spans, original
+	default:
+		if part.IsNumber {
+			return phonetic, phoneticSpans, phonetic
+		}
 
-			stress := selection.Stress
-			if len(selection.Syllables) == 1 {
-				stress = -1
-			}
+		return phonetic, phoneticSpans, part.Raw
+	}
+}
 
-			err := litxaputil.WriteSyllablesAsIPATo(sb, syllables, syllableDelimiter, []int{stress}, []int{})
-			if err != nil {
-				return "", err
-			}
-		} else {
-			sb.WriteString(part.Raw)
-		}
+// canSeparateSyllables is false if there's a space or hyphen between the syllables, as these
+// already work as separators.
+func canSeparateSyllables(prev, next string) bool {
+	return strings.TrimSpace(prev) != "" && strings.TrimSpace(next) != "" &&
+		!strings.HasSuffix(prev, "-") && !strings.HasPrefix(next, "-")
+}
+
+// IPA transcribes the line with the stress marks of the selected matches. It fails on the first word that
+// cannot be transcribed, see IPAWithOptions for a version that doesn't.
+func (line Line) IPA(selections map[int]int, syllableDelimiter string) (string, error) {
+	res := line.IPAWithOptions(selections, IPAOptions{SyllableDelimiter: syllableDelimiter})
+	if err := res.Err(); err != nil {
+		return "", err
 	}
 
-	return sb.String(), nil
+	return res.String(), nil
 }
 
 // ParseLine splits out the words from a line of text.
@@ -187,7 +265,7 @@ func (line Line) runWithCache(dict Dictionary, dictCache map[string][]Entry, run
 	newLine := append(line[:0:0], line...)
 
 	for i, part := range newLine {
-		if !part.IsWord {
+		if !part.IsWord || part.IsNumber {
 			continue
 		}
 
@@ -218,12 +296,13 @@ func (line Line) runWithCache(dict Dictionary, dictCache map[string][]Entry, run
 		} else {
 			newLine[i].Matches = make([]LinePartMatch, 0, len(results))
 			for _, result := range results {
-				syllables, stress := RunWord(part.Raw, result)
+				syllables, stress, secondaryStress := RunWordWithSecondaryStress(part.Raw, result)
 				if syllables != nil {
 					newLine[i].Matches = append(newLine[i].Matches, LinePartMatch{
-						Syllables: syllables,
-						Stress:    stress,
-						Entry:     result,
+						Syllables:       syllables,
+						Stress:          stress,
+						SecondaryStress: secondaryStress,
+						Entry:           result,
 					})
 				}
 			}
@@ -285,14 +364,63 @@ func (line Line) WithSelections(selections map[int]int, firstByDefault bool) Lin
 	return newLine
 }
 
+// SelectPartOfSpeech adds a selection for the words with more than one match where only one of them has a part of
+// speech that is accepted. The selections that are already made are kept, and a new map is returned.
+func (line Line) SelectPartOfSpeech(selections map[int]int, accept func(pos litxaputil.PartOfSpeech) bool) map[int]int {
+	res := make(map[int]int, len(selections))
+	for i, selection := range selections {
+		res[i] = selection
+	}
+
+	for i, part := range line {
+		if _, ok := res[i]; ok || len(part.Matches) < 2 {
+			continue
+		}
+
+		found := -1
+		for j, match := range part.Matches {
+			if accept(match.Entry.PartOfSpeech) {
+				if found != -1 {
+					found = -1
+					break
+				}
+
+				found = j
+			}
+		}
+
+		if found != -1 {
+			res[i] = found
+		}
+	}
+
+	return res
+}
+
 type LinePart struct {
-	Raw     string          `json:"raw"`
-	Lookup  string          `json:"lookup,omitempty"`
-	IsWord  bool            `json:"isWord,omitempty"`
-	Matches []LinePartMatch `json:"matches,omitempty"`
+	Raw    string `json:"raw"`
+	Lookup string `json:"lookup,omitempty"`
+	// Original is the Raw from before it was changed by a filter, if it has been.
+	Original string `json:"original,omitempty"`
+	IsWord   bool   `json:"isWord,omitempty"`
+	// IsNumber is set for the numbers Line.ConvertNumbers made into words. Raw has the digits, and the match has
+	// the Na'vi number.
+	IsNumber bool            `json:"isNumber,omitempty"`
+	Matches  []LinePartMatch `json:"matches,omitempty"`
 }
 
 func (part *LinePart) GetSyllables(selection int) ([]string, int) {
+	match, stress := part.SelectedMatch(selection)
+	if match == nil {
+		return nil, stress
+	}
+
+	return match.Syllables, stress
+}
+
+// SelectedMatch works like GetSyllables, but gives the whole match. The match is nil when
+// the stress is LPSNotWord, LPSNoMatches or LPSAmbiguousMatches.
+func (part *LinePart) SelectedMatch(selection int) (*LinePartMatch, int) {
 	// If it's not a word, there's no need for syllables
 	if !part.IsWord {
 		return nil, LPSNotWord
@@ -305,7 +433,7 @@ func (part *LinePart) GetSyllables(selection int) ([]string, int) {
 
 	// If there is a valid selection, take the selected part.
 	if selection >= 0 && selection < len(part.Matches) {
-		return part.Matches[selection].Syllables, part.Matches[selection].Stress
+		return &part.Matches[selection], part.Matches[selection].Stress
 	}
 
 	// If there is no selection, allow only if every match agree on stress.
@@ -315,23 +443,68 @@ func (part *LinePart) GetSyllables(selection int) ([]string, int) {
 			// If there are multiple stresses
 			// a last any option should be allowed for ìlä, tsatseng, ayfo, etc...
 			if selection == len(part.Matches) {
-				return part.Matches[0].Syllables, LPSAnyStress
+				return &part.Matches[0], LPSAnyStress
 			}
 
 			return nil, LPSAmbiguousMatches
 		}
 	}
 
-	return part.Matches[0].Syllables, part.Matches[0].Stress
+	return &part.Matches[0], part.Matches[0].Stress
 }
 
 type LinePartMatch struct {
-	Syllables    []string `json:"syllables"`
-	Stress       int      `json:"stress"`
-	Entry        Entry    `json:"entry"`
-	StressedWord bool     `json:"stressedWord,omitempty"`
+	Syllables []string `json:"syllables"`
+	Stress    int      `json:"stress"`
+	// SecondaryStress has the indices of the syllables with a weaker stress, which is common in long compounds,
+	// numbers and multi-word entries.
+	SecondaryStress []int `json:"secondaryStress,omitempty"`
+	Entry           Entry `json:"entry"`
+	StressedWord    bool  `json:"stressedWord,omitempty"`
+	// ChangedSyllables has the indices of the syllables that were changed by filters, if they were asked to report it.
+	ChangedSyllables []int `json:"changedSyllables,omitempty"`
 }
 
+// SyllableStructure parses the syllables into their onsets, bodies and codas, keeping the casing. The syllables
+// that are only whitespace, like the ones between the words of multi-word entries, are left as zero values. It
+// returns false if any other syllable could not be parsed, which can happen after some filters.
+func (match *LinePartMatch) SyllableStructure() ([]litxaputil.Syllable, bool) {
+	res := make([]litxaputil.Syllable, len(match.Syllables))
+	ok := true
+	for i, syllable := range match.Syllables {
+		if strings.TrimSpace(syllable) == "" {
+			continue
+		}
+
+		parsed, parsedOK := litxaputil.ParseSyllable(syllable)
+		if !parsedOK {
+			ok = false
+			continue
+		}
+
+		res[i] = parsed
+	}
+
+	return res, ok
+}
+
+// FormatMode decides what text Line.FormatWithMode puts the stress marks on.
+type FormatMode int
+
+const (
+	// FormatDefault writes the syllables of stressed words, and the Raw text of the rest. The numbers from
+	// Line.ConvertNumbers are always written as Na'vi.
+	FormatDefault FormatMode = iota
+	// FormatOriginal puts the stress marks on the text from before any filters were applied, keeping
+	// the original spelling and casing. If the stressed syllable was removed entirely by a filter, it
+	// will fall back to the phonetic spelling for that word. The numbers from Line.ConvertNumbers are
+	// written with their digits.
+	FormatOriginal
+	// FormatPhonetic always writes the syllables of the selected match, which for a filtered line is the
+	// phonetic spelling.
+	FormatPhonetic
+)
+
 const LPSNoMatches = -2
 const LPSAmbiguousMatches = -3
 const LPSNotWord = -4
@@ -341,3 +514,42 @@ type LineFormatter interface {
 	LinePartTags(lp LinePart, stress int) (string, string)
 	StressedSyllableTags() (string, string)
 }
+
+// LineTextEscaper can be implemented by a LineFormatter for markup languages where the text
+// needs to be escaped. It will be used on the raw text and each syllable, but not on the tags.
+type LineTextEscaper interface {
+	EscapeText(s string) string
+}
+
+// LineSyllableSeparator can be implemented by a LineFormatter to put a separator between the
+// syllables of a word, like the middle dot used in dictionaries.
+type LineSyllableSeparator interface {
+	SyllableSeparator() string
+}
+
+// LineSecondaryStressFormatter can be implemented by a LineFormatter to mark the syllables with secondary
+// stress differently from the stressed syllable. They are left unmarked otherwise.
+type LineSecondaryStressFormatter interface {
+	SecondaryStressedSyllableTags() (string, string)
+}
+
+// LineChangedSyllableFormatter can be implemented by a LineFormatter to mark the syllables in the match's
+// ChangedSyllables. These tags go outside the stress tags.
+type LineChangedSyllableFormatter interface {
+	ChangedSyllableTags() (string, string)
+}
+
+// RichLineFormatter is a LineFormatter with hooks for every word and syllable. Line.Format will
+// use WordTags and SyllableTags instead of LinePartTags and StressedSyllableTags for the words, but
+// LinePartTags is still used for the parts in-between.
+type RichLineFormatter interface {
+	LineFormatter
+	LineTextEscaper
+
+	// WordTags wraps a word. The match is the selected one, or nil if there is no match or
+	// the matches are ambiguous. The stress is the same as the one given to LinePartTags.
+	WordTags(part LinePart, match *LinePartMatch, stress int) (string, string)
+	// SyllableTags wraps the syllable at the index of the match's syllables. Unlike StressedSyllableTags,
+	// this is also used on words with one syllable. The secondary stress can be found in the match.
+	SyllableTags(match *LinePartMatch, index int, stressed bool) (string, string)
+}
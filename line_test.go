@@ -2,8 +2,10 @@ package litxap
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/gissleh/litxap/litxaputil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,66 +60,81 @@ var dummyDictionary = DummyDictionary{
 	"talun:0":       *ParseEntry("ta.*lun"),
 	"holahaw":       *ParseEntry("*h·a.h·aw: <ol>"),
 	"nìtam":         *ParseEntry("nì.*tam"),
+	"mrrvomrr":      *ParseEntry("ˌmrr.vo.*mrr"),
+	"fmetokmungwrr": *ParseEntry("fme.tok: -mungwrr"),
+	"tsyal":         *ParseEntry("tsyal"),
+	"kxetse":        *ParseEntry("*kxe.tse"),
 }
 
 var lineOelNgatiKameie = Line{
 	LinePart{Raw: "Oel", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"Oel"}, 0, dummyDictionary["oel"], false},
+		{Syllables: []string{"Oel"}, Stress: 0, Entry: dummyDictionary["oel"]},
 	}},
 	LinePart{Raw: " "},
 	LinePart{Raw: "ngati", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"nga", "ti"}, 0, dummyDictionary["ngati"], false},
+		{Syllables: []string{"nga", "ti"}, Stress: 0, Entry: dummyDictionary["ngati"]},
 	}},
 	LinePart{Raw: " "},
 	LinePart{Raw: "kameie", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"ka", "me", "i", "e"}, 0, dummyDictionary["kameie"], false},
+		{Syllables: []string{"ka", "me", "i", "e"}, Stress: 0, Entry: dummyDictionary["kameie"]},
 	}},
 	LinePart{Raw: "."},
 }
 
 var lineKaltxiMaFmetokyu = Line{
 	LinePart{Raw: "Kaltxì", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"Kal", "txì"}, 1, dummyDictionary["kaltxì"], false},
+		{Syllables: []string{"Kal", "txì"}, Stress: 1, Entry: dummyDictionary["kaltxì"]},
 	}},
 	LinePart{Raw: ", "},
 	LinePart{Raw: "ma", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"ma"}, 0, dummyDictionary["ma"], false},
+		{Syllables: []string{"ma"}, Stress: 0, Entry: dummyDictionary["ma"]},
 	}},
 	LinePart{Raw: " "},
 	LinePart{Raw: "fmetokyu", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"fme", "tok", "yu"}, 0, dummyDictionary["fmetokyu"], false},
+		{Syllables: []string{"fme", "tok", "yu"}, Stress: 0, Entry: dummyDictionary["fmetokyu"]},
 	}},
 	LinePart{Raw: "!"},
 }
 
 var lineKaltxiMaFmetan = Line{
 	LinePart{Raw: "Kaltxì", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"Kal", "txì"}, 1, dummyDictionary["kaltxì"], false},
+		{Syllables: []string{"Kal", "txì"}, Stress: 1, Entry: dummyDictionary["kaltxì"]},
 	}},
 	LinePart{Raw: ", "},
 	LinePart{Raw: "ma", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"ma"}, 0, dummyDictionary["ma"], false},
+		{Syllables: []string{"ma"}, Stress: 0, Entry: dummyDictionary["ma"]},
 	}},
 	LinePart{Raw: " "},
 	LinePart{Raw: "Fmetan", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"Fme", "tan"}, 0, dummyDictionary["fmetan"], false},
-		{[]string{"Fme", "tan"}, 1, dummyDictionary["fmetan:0"], false},
+		{Syllables: []string{"Fme", "tan"}, Stress: 0, Entry: dummyDictionary["fmetan"]},
+		{Syllables: []string{"Fme", "tan"}, Stress: 1, Entry: dummyDictionary["fmetan:0"]},
 	}},
 	LinePart{Raw: "!"},
 }
 
 var lineVolaSkeynven = Line{
 	LinePart{Raw: "Vola", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"Vo", "la"}, 0, dummyDictionary["vola"], false},
+		{Syllables: []string{"Vo", "la"}, Stress: 0, Entry: dummyDictionary["vola"]},
 	}},
 	LinePart{Raw: " "},
 	LinePart{Raw: "skeynven", IsWord: true},
 	LinePart{Raw: "."},
 }
 
+var lineMrrvomrrFmetokmungwrr = Line{
+	LinePart{Raw: "Mrrvomrr", IsWord: true, Matches: []LinePartMatch{
+		{Syllables: []string{"Mrr", "vo", "mrr"}, Stress: 2, SecondaryStress: []int{0}, Entry: dummyDictionary["mrrvomrr"]},
+	}},
+	LinePart{Raw: " "},
+	LinePart{Raw: "fmetokmungwrr", IsWord: true, Matches: []LinePartMatch{
+		{Syllables: []string{"fme", "tok", "mung", "wrr"}, Stress: 0, SecondaryStress: []int{2}, Entry: dummyDictionary["fmetokmungwrr"]},
+	}},
+	LinePart{Raw: "."},
+}
+
 var lineFmetokBad = Line{
 	LinePart{Raw: "Vola", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"Fme", "tök"}, 0, dummyDictionary["fmetok"], false},
+		{Syllables: []string{"Fme", "tök"}, Stress: 0, Entry: dummyDictionary["fmetok"]},
 	}},
 }
 
@@ -134,35 +151,39 @@ func TestRunLine(t *testing.T) {
 			input:    "Oel ngati kameie.",
 			expected: lineOelNgatiKameie,
 		},
+		{
+			input:    "Mrrvomrr fmetokmungwrr.",
+			expected: lineMrrvomrrFmetokmungwrr,
+		},
 		{
 			input: "Ayhapxìtu soaiä ngeyä lu oeru let'eylan nìwotx.",
 			expected: Line{
 				LinePart{Raw: "Ayhapxìtu", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"Ay", "ha", "pxì", "tu"}, 2, dummyDictionary["ayhapxìtu"], false},
+					{Syllables: []string{"Ay", "ha", "pxì", "tu"}, Stress: 2, Entry: dummyDictionary["ayhapxìtu"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "soaiä", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"so", "a", "i", "ä"}, 1, dummyDictionary["soaiä"], false},
+					{Syllables: []string{"so", "a", "i", "ä"}, Stress: 1, Entry: dummyDictionary["soaiä"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "ngeyä", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"nge", "yä"}, 0, dummyDictionary["ngeyä"], false},
+					{Syllables: []string{"nge", "yä"}, Stress: 0, Entry: dummyDictionary["ngeyä"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "lu", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"lu"}, 0, dummyDictionary["lu"], false},
+					{Syllables: []string{"lu"}, Stress: 0, Entry: dummyDictionary["lu"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "oeru", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"oe", "ru"}, 0, dummyDictionary["oeru"], false},
+					{Syllables: []string{"oe", "ru"}, Stress: 0, Entry: dummyDictionary["oeru"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "let'eylan", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"let", "'ey", "lan"}, 1, dummyDictionary["let'eylan"], false},
+					{Syllables: []string{"let", "'ey", "lan"}, Stress: 1, Entry: dummyDictionary["let'eylan"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "nìwotx", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"nì", "wotx"}, 1, dummyDictionary["nìwotx"], false},
+					{Syllables: []string{"nì", "wotx"}, Stress: 1, Entry: dummyDictionary["nìwotx"]},
 				}},
 				LinePart{Raw: "."},
 			},
@@ -175,11 +196,11 @@ func TestRunLine(t *testing.T) {
 			input: "Vola säkeynven|skeynven.",
 			expected: Line{
 				LinePart{Raw: "Vola", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"Vo", "la"}, 0, dummyDictionary["vola"], false},
+					{Syllables: []string{"Vo", "la"}, Stress: 0, Entry: dummyDictionary["vola"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "skeynven", Lookup: "säkeynven", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"skeyn", "ven"}, 1, dummyDictionary["säkeynven"], false},
+					{Syllables: []string{"skeyn", "ven"}, Stress: 1, Entry: dummyDictionary["säkeynven"]},
 				}},
 				LinePart{Raw: "."},
 			},
@@ -188,15 +209,15 @@ func TestRunLine(t *testing.T) {
 			input: "Lu oer tìnitram.", // This line crashes 1.13.2
 			expected: Line{
 				LinePart{Raw: "Lu", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"Lu"}, 0, dummyDictionary["lu"], false},
+					{Syllables: []string{"Lu"}, Stress: 0, Entry: dummyDictionary["lu"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "oer", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"oer"}, 0, dummyDictionary["oer"], false},
+					{Syllables: []string{"oer"}, Stress: 0, Entry: dummyDictionary["oer"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "tìnitram", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"tì", "nit", "ram"}, 2, dummyDictionary["tìnitram"], false},
+					{Syllables: []string{"tì", "nit", "ram"}, Stress: 2, Entry: dummyDictionary["tìnitram"]},
 				}},
 				LinePart{Raw: "."},
 			},
@@ -205,19 +226,19 @@ func TestRunLine(t *testing.T) {
 			input: "Po tsaheyl soli ikranhu.",
 			expected: Line{
 				LinePart{Raw: "Po", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"Po"}, 0, dummyDictionary["po"], false},
+					{Syllables: []string{"Po"}, Stress: 0, Entry: dummyDictionary["po"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "tsaheyl", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"tsa", "heyl"}, -1, dummyDictionary["tsaheyl"], false},
+					{Syllables: []string{"tsa", "heyl"}, Stress: -1, Entry: dummyDictionary["tsaheyl"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "soli", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"so", "li"}, 1, dummyDictionary["soli"], false},
+					{Syllables: []string{"so", "li"}, Stress: 1, Entry: dummyDictionary["soli"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "ikranhu", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"ik", "ran", "hu"}, 0, dummyDictionary["ikranhu"], false},
+					{Syllables: []string{"ik", "ran", "hu"}, Stress: 0, Entry: dummyDictionary["ikranhu"]},
 				}},
 				LinePart{Raw: "."},
 			},
@@ -226,32 +247,32 @@ func TestRunLine(t *testing.T) {
 			input: "Tslolam oel futa ke frapo ke tslolam.",
 			expected: Line{
 				LinePart{Raw: "Tslolam", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"Tslo", "lam"}, 1, dummyDictionary["tslolam"], false},
+					{Syllables: []string{"Tslo", "lam"}, Stress: 1, Entry: dummyDictionary["tslolam"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "oel", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"oel"}, 0, dummyDictionary["oel"], false},
+					{Syllables: []string{"oel"}, Stress: 0, Entry: dummyDictionary["oel"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "futa", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"fu", "ta"}, 0, dummyDictionary["futa"], false},
+					{Syllables: []string{"fu", "ta"}, Stress: 0, Entry: dummyDictionary["futa"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "ke", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"ke"}, 0, dummyDictionary["ke"], false},
+					{Syllables: []string{"ke"}, Stress: 0, Entry: dummyDictionary["ke"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "frapo", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"fra", "po"}, 0, dummyDictionary["frapo"], false},
-					{[]string{"fra", "po"}, 1, dummyDictionary["frapo:0"], false},
+					{Syllables: []string{"fra", "po"}, Stress: 0, Entry: dummyDictionary["frapo"]},
+					{Syllables: []string{"fra", "po"}, Stress: 1, Entry: dummyDictionary["frapo:0"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "ke", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"ke"}, 0, dummyDictionary["ke"], false},
+					{Syllables: []string{"ke"}, Stress: 0, Entry: dummyDictionary["ke"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "tslolam", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"tslo", "lam"}, 1, dummyDictionary["tslolam"], false},
+					{Syllables: []string{"tslo", "lam"}, Stress: 1, Entry: dummyDictionary["tslolam"]},
 				}},
 				LinePart{Raw: "."},
 			},
@@ -260,15 +281,15 @@ func TestRunLine(t *testing.T) {
 			input: "Oe tsaktap si.",
 			expected: Line{
 				LinePart{Raw: "Oe", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"O", "e"}, 0, dummyDictionary["oe"], false},
+					{Syllables: []string{"O", "e"}, Stress: 0, Entry: dummyDictionary["oe"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "tsaktap", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"tsak", "tap"}, 0, dummyDictionary["tsaktap"], false},
+					{Syllables: []string{"tsak", "tap"}, Stress: 0, Entry: dummyDictionary["tsaktap"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "si", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"si"}, 0, dummyDictionary["si"], false},
+					{Syllables: []string{"si"}, Stress: 0, Entry: dummyDictionary["si"]},
 				}},
 				LinePart{Raw: "."},
 			},
@@ -277,15 +298,15 @@ func TestRunLine(t *testing.T) {
 			input: "Oe uvan si.",
 			expected: Line{
 				LinePart{Raw: "Oe", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"O", "e"}, 0, dummyDictionary["oe"], false},
+					{Syllables: []string{"O", "e"}, Stress: 0, Entry: dummyDictionary["oe"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "uvan", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"u", "van"}, 1, dummyDictionary["uvan"], false},
+					{Syllables: []string{"u", "van"}, Stress: 1, Entry: dummyDictionary["uvan"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "si", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"si"}, 0, dummyDictionary["si"], false},
+					{Syllables: []string{"si"}, Stress: 0, Entry: dummyDictionary["si"]},
 				}},
 				LinePart{Raw: "."},
 			},
@@ -294,15 +315,15 @@ func TestRunLine(t *testing.T) {
 			input: "'EFU OE NITRAM!",
 			expected: Line{
 				LinePart{Raw: "'EFU", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"'E", "FU"}, 0, dummyDictionary["'efu"], false},
+					{Syllables: []string{"'E", "FU"}, Stress: 0, Entry: dummyDictionary["'efu"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "OE", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"OE"}, 0, dummyDictionary["oe"], false},
+					{Syllables: []string{"OE"}, Stress: 0, Entry: dummyDictionary["oe"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "NITRAM", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"NIT", "RAM"}, 1, dummyDictionary["nitram"], false},
+					{Syllables: []string{"NIT", "RAM"}, Stress: 1, Entry: dummyDictionary["nitram"]},
 				}},
 				LinePart{Raw: "!"},
 			},
@@ -311,36 +332,36 @@ func TestRunLine(t *testing.T) {
 			input: "'Efu oe ngeyn talun oe ke holahaw nìtam.",
 			expected: Line{
 				LinePart{Raw: "'Efu", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"'E", "fu"}, 0, dummyDictionary["'efu"], false},
+					{Syllables: []string{"'E", "fu"}, Stress: 0, Entry: dummyDictionary["'efu"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "oe", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"oe"}, 0, dummyDictionary["oe"], false},
+					{Syllables: []string{"oe"}, Stress: 0, Entry: dummyDictionary["oe"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "ngeyn", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"ngeyn"}, 0, dummyDictionary["ngeyn"], false},
+					{Syllables: []string{"ngeyn"}, Stress: 0, Entry: dummyDictionary["ngeyn"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "talun", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"ta", "lun"}, 1, dummyDictionary["talun"], false},
-					{[]string{"ta", "lun"}, 1, dummyDictionary["talun:0"], false},
+					{Syllables: []string{"ta", "lun"}, Stress: 1, Entry: dummyDictionary["talun"]},
+					{Syllables: []string{"ta", "lun"}, Stress: 1, Entry: dummyDictionary["talun:0"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "oe", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"o", "e"}, 0, dummyDictionary["oe"], false},
+					{Syllables: []string{"o", "e"}, Stress: 0, Entry: dummyDictionary["oe"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "ke", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"ke"}, 0, dummyDictionary["ke"], false},
+					{Syllables: []string{"ke"}, Stress: 0, Entry: dummyDictionary["ke"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "holahaw", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"ho", "la", "haw"}, 1, dummyDictionary["holahaw"], false},
+					{Syllables: []string{"ho", "la", "haw"}, Stress: 1, Entry: dummyDictionary["holahaw"]},
 				}},
 				LinePart{Raw: " "},
 				LinePart{Raw: "nìtam", IsWord: true, Matches: []LinePartMatch{
-					{[]string{"nì", "tam"}, 1, dummyDictionary["nìtam"], false},
+					{Syllables: []string{"nì", "tam"}, Stress: 1, Entry: dummyDictionary["nìtam"]},
 				}},
 				LinePart{Raw: "."},
 			},
@@ -501,17 +522,17 @@ func (f *dummyLineFormatter) StressedSyllableTags() (string, string) {
 
 var lineFikemIlaFyao = Line{
 	LinePart{Raw: "Fìkem", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"Fì", "kem"}, 1, dummyDictionary["fìkem"], false},
-		{[]string{"Fì", "kem"}, 1, dummyDictionary["fìkem:0"], false},
+		{Syllables: []string{"Fì", "kem"}, Stress: 1, Entry: dummyDictionary["fìkem"]},
+		{Syllables: []string{"Fì", "kem"}, Stress: 1, Entry: dummyDictionary["fìkem:0"]},
 	}},
 	LinePart{Raw: " "},
 	LinePart{Raw: "ìlä", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"ì", "lä"}, 0, dummyDictionary["ìlä"], false},
-		{[]string{"ì", "lä"}, 1, dummyDictionary["ìlä:0"], false},
+		{Syllables: []string{"ì", "lä"}, Stress: 0, Entry: dummyDictionary["ìlä"]},
+		{Syllables: []string{"ì", "lä"}, Stress: 1, Entry: dummyDictionary["ìlä:0"]},
 	}},
 	LinePart{Raw: " "},
 	LinePart{Raw: "fya'o", IsWord: true, Matches: []LinePartMatch{
-		{[]string{"fya", "'o"}, 0, dummyDictionary["fya'o"], false},
+		{Syllables: []string{"fya", "'o"}, Stress: 0, Entry: dummyDictionary["fya'o"]},
 	}},
 	LinePart{Raw: "!"},
 }
@@ -529,6 +550,7 @@ func TestLine_Format(t *testing.T) {
 		{lineKaltxiMaFmetan, "[S]Kal{txì}[/S], [S]ma[/S] [S]Fme{tan}[/S]!", map[int]int{4: 1}},
 		{lineVolaSkeynven, "[S]{Vo}la[/S] [NM]skeynven[/NM].", nil},
 		{lineFikemIlaFyao, "[S]Fì{kem}[/S] [AS]ìlä[/AS] [S]{fya}'o[/S]!", map[int]int{2: 2}},
+		{lineMrrvomrrFmetokmungwrr, "[S]Mrrvo{mrr}[/S] [S]{fme}tokmungwrr[/S].", nil},
 	}
 
 	for _, row := range table {
@@ -538,6 +560,77 @@ func TestLine_Format(t *testing.T) {
 	}
 }
 
+type dummySecondaryStressFormatter struct {
+	dummyLineFormatter
+}
+
+func (f *dummySecondaryStressFormatter) SecondaryStressedSyllableTags() (string, string) {
+	return "<", ">"
+}
+
+func TestLine_Format_SecondaryStress(t *testing.T) {
+	table := []struct {
+		input      Line
+		output     string
+		selections map[int]int
+	}{
+		{lineMrrvomrrFmetokmungwrr, "[S]<Mrr>vo{mrr}[/S] [S]{fme}tok<mung>wrr[/S].", nil},
+		{lineKaltxiMaFmetokyu, "[S]Kal{txì}[/S], [S]ma[/S] [S]{fme}tokyu[/S]!", nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.output, func(t *testing.T) {
+			assert.Equal(t, row.output, row.input.Format(&dummySecondaryStressFormatter{}, row.selections))
+		})
+	}
+}
+
+type dummyRichLineFormatter struct {
+	dummyLineFormatter
+}
+
+func (f *dummyRichLineFormatter) EscapeText(s string) string {
+	return strings.ReplaceAll(s, "'", "&#39;")
+}
+
+func (f *dummyRichLineFormatter) WordTags(part LinePart, match *LinePartMatch, stress int) (string, string) {
+	if match == nil {
+		open, close := f.LinePartTags(part, stress)
+		return open, close
+	}
+
+	return fmt.Sprintf("[W %q]", match.Entry.Translation), "[/W]"
+}
+
+func (f *dummyRichLineFormatter) SyllableTags(match *LinePartMatch, index int, stressed bool) (string, string) {
+	if stressed {
+		return fmt.Sprintf("{%d:", index), "}"
+	}
+
+	return fmt.Sprintf("(%d:", index), ")"
+}
+
+func TestLine_Format_Rich(t *testing.T) {
+	table := []struct {
+		input      Line
+		output     string
+		selections map[int]int
+	}{
+		{lineKaltxiMaFmetokyu, `[W ""](0:Kal){1:txì}[/W], [W ""]{0:ma}[/W] [W ""]{0:fme}(1:tok)(2:yu)[/W]!`, nil},
+		{lineKaltxiMaFmetan, `[W ""](0:Kal){1:txì}[/W], [W ""]{0:ma}[/W] [AM]Fmetan[/AM]!`, nil},
+		{lineKaltxiMaFmetan, `[W ""](0:Kal){1:txì}[/W], [W ""]{0:ma}[/W] [W ""](0:Fme){1:tan}[/W]!`, map[int]int{4: 1}},
+		{lineVolaSkeynven, `[W ""]{0:Vo}(1:la)[/W] [NM]skeynven[/NM].`, nil},
+		{lineFikemIlaFyao, `[W ""](0:Fì){1:kem}[/W] [W ""](0:ì)(1:lä)[/W] [W ""]{0:fya}(1:&#39;o)[/W]!`, map[int]int{2: 2}},
+		{lineOelNgatiKameie, `[W ""]{0:Oel}[/W] [W ""]{0:nga}(1:ti)[/W] [W "see, see into, understand, know (spiritual sense)"]{0:ka}(1:me)(2:i)(3:e)[/W].`, nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.output, func(t *testing.T) {
+			assert.Equal(t, row.output, row.input.Format(&dummyRichLineFormatter{}, row.selections))
+		})
+	}
+}
+
 func TestLine_IPA(t *testing.T) {
 	table := []struct {
 		input      Line
@@ -549,9 +642,11 @@ func TestLine_IPA(t *testing.T) {
 		{lineOelNgatiKameie, "", "wɛl ˈŋati ˈkamɛiɛ.", nil, ""},
 		{lineKaltxiMaFmetokyu, "", "kalˈtʼɪ, ma ˈfmɛtok̚ju!", nil, ""},
 		{lineFikemIlaFyao, ".", "fɪ.ˈkɛm ɪ.ˈlæ ˈfja.ʔo!", map[int]int{2: 1}, ""},
-		{lineFikemIlaFyao, ".", "fɪ.ˈkɛm ˈɪ.læ ˈfja.ʔo!", map[int]int{2: 2}, ""},
+		{lineFikemIlaFyao, ".", "fɪ.ˈkɛm ˈɪ.læ ˈfja.ʔo!", map[int]int{2: 0}, ""},
+		{lineFikemIlaFyao, ".", "fɪ.ˈkɛm ɪ.læ ˈfja.ʔo!", map[int]int{2: 2}, ""},
 		{lineKaltxiMaFmetan, "", "kalˈtʼɪ, ma ˈfmɛtan!", map[int]int{4: 0}, ""},
 		{lineKaltxiMaFmetan, "", "kalˈtʼɪ, ma fmɛˈtan!", map[int]int{4: 1}, ""},
+		{lineMrrvomrrFmetokmungwrr, ".", "ˌmr̩.vo.ˈmr̩ ˈfmɛ.tok̚.ˌmuŋ.wr̩.", nil, ""},
 		{lineVolaSkeynven, "", "", nil, fmt.Sprintf("no matches for line[%d] (%#+v)", 2, "skeynven")},
 		{lineFmetokBad, "", "", nil, "unknown symbols [\"ö\", \"ök\"] in syllable tök"},
 	}
@@ -575,31 +670,81 @@ func TestLine_WithSelections(t *testing.T) {
 
 	assert.Equal(t, Line{
 		LinePart{Raw: "Kaltxì", IsWord: true, Matches: []LinePartMatch{
-			{[]string{"Kal", "txì"}, 1, dummyDictionary["kaltxì"], false},
+			{Syllables: []string{"Kal", "txì"}, Stress: 1, Entry: dummyDictionary["kaltxì"]},
 		}},
 		LinePart{Raw: ", "},
 		LinePart{Raw: "ma", IsWord: true, Matches: []LinePartMatch{
-			{[]string{"ma"}, 0, dummyDictionary["ma"], false},
+			{Syllables: []string{"ma"}, Stress: 0, Entry: dummyDictionary["ma"]},
 		}},
 		LinePart{Raw: " "},
 		LinePart{Raw: "Fmetan", IsWord: true, Matches: []LinePartMatch{
-			{[]string{"Fme", "tan"}, 0, dummyDictionary["fmetan"], false},
+			{Syllables: []string{"Fme", "tan"}, Stress: 0, Entry: dummyDictionary["fmetan"]},
 		}},
 		LinePart{Raw: "!"},
 	}, lineKaltxiMaFmetan.WithSelections(nil, true))
 
 	assert.Equal(t, Line{
 		LinePart{Raw: "Kaltxì", IsWord: true, Matches: []LinePartMatch{
-			{[]string{"Kal", "txì"}, 1, dummyDictionary["kaltxì"], false},
+			{Syllables: []string{"Kal", "txì"}, Stress: 1, Entry: dummyDictionary["kaltxì"]},
 		}},
 		LinePart{Raw: ", "},
 		LinePart{Raw: "ma", IsWord: true, Matches: []LinePartMatch{
-			{[]string{"ma"}, 0, dummyDictionary["ma"], false},
+			{Syllables: []string{"ma"}, Stress: 0, Entry: dummyDictionary["ma"]},
 		}},
 		LinePart{Raw: " "},
 		LinePart{Raw: "Fmetan", IsWord: true, Matches: []LinePartMatch{
-			{[]string{"Fme", "tan"}, 1, dummyDictionary["fmetan:0"], false},
+			{Syllables: []string{"Fme", "tan"}, Stress: 1, Entry: dummyDictionary["fmetan:0"]},
 		}},
 		LinePart{Raw: "!"},
 	}, lineKaltxiMaFmetan.WithSelections(map[int]int{4: 1}, false))
 }
+
+func TestLine_SelectPartOfSpeech(t *testing.T) {
+	line := Line{
+		{Raw: "Ayfo", IsWord: true, Matches: []LinePartMatch{
+			{Syllables: []string{"Ay", "fo"}, Stress: 1, Entry: *ParseEntry("ay.*fo: $pos:pn.: they")},
+			{Syllables: []string{"Ay", "fo"}, Stress: 0, Entry: *ParseEntry("fo: ay- $pos:pn.: they")},
+		}},
+		{Raw: " "},
+		{Raw: "tute", IsWord: true, Matches: []LinePartMatch{
+			{Syllables: []string{"tu", "te"}, Stress: 0, Entry: *ParseEntry("tu.te: $pos:n.: person")},
+			{Syllables: []string{"tu", "te"}, Stress: 0, Entry: *ParseEntry("tu.te: $pos:adj.: made-up")},
+		}},
+		{Raw: " "},
+		{Raw: "kameie", IsWord: true, Matches: []LinePartMatch{
+			{Syllables: []string{"ka", "me", "i", "e"}, Stress: 0, Entry: *ParseEntry("k·a.m·e: <ei> $pos:vtr.: see")},
+			{Syllables: []string{"ka", "me", "i", "e"}, Stress: 3, Entry: *ParseEntry("k··ä: <am,ei> $pos:vin.: go")},
+		}},
+	}
+
+	assert.Equal(t, map[int]int{2: 0}, line.SelectPartOfSpeech(nil, litxaputil.PartOfSpeech.IsNoun))
+	assert.Equal(t, map[int]int{2: 1, 4: 1}, line.SelectPartOfSpeech(map[int]int{2: 1}, func(pos litxaputil.PartOfSpeech) bool {
+		return pos == litxaputil.PoSVerbIn
+	}))
+}
+
+func TestLinePartMatch_SyllableStructure(t *testing.T) {
+	match := lineMrrvomrrFmetokmungwrr[0].Matches[0]
+	res, ok := match.SyllableStructure()
+	assert.True(t, ok)
+	assert.Equal(t, []litxaputil.Syllable{
+		{Onset: "M", Body: "rr"},
+		{Onset: "v", Body: "o"},
+		{Onset: "m", Body: "rr"},
+	}, res)
+
+	match = LinePartMatch{Syllables: []string{"u", "van", " ", "si"}}
+	res, ok = match.SyllableStructure()
+	assert.True(t, ok)
+	assert.Equal(t, []litxaputil.Syllable{{Body: "u"}, {Onset: "v", Body: "a", Coda: "n"}, {}, {Onset: "s", Body: "i"}}, res)
+
+	// Oel is one syllable in the dictionary, even if SplitSyllables would make it two.
+	res, ok = lineOelNgatiKameie[0].Matches[0].SyllableStructure()
+	assert.True(t, ok)
+	assert.Equal(t, []litxaputil.Syllable{{Onset: "O", Body: "e", Coda: "l"}}, res)
+
+	match = LinePartMatch{Syllables: []string{"fme", "-g"}}
+	res, ok = match.SyllableStructure()
+	assert.False(t, ok)
+	assert.Equal(t, []litxaputil.Syllable{{PreOnset: "f", Onset: "m", Body: "e"}, {}}, res)
+}
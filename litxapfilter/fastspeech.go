@@ -0,0 +1,134 @@
+package litxapfilter
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Strength is how far the fast speech filters go. Each strength does everything the weaker ones do.
+type Strength int
+
+const (
+	// StrengthLight only makes the changes that are heard even in careful speech.
+	StrengthLight Strength = iota
+	// StrengthMedium is for normal casual speech.
+	StrengthMedium
+	// StrengthStrong is for very fast speech.
+	StrengthStrong
+)
+
+// ReducedI is the spelling ReduceUnstressedI uses for a reduced ì. It is also the IPA for it, and the transcriptions
+// in litxaputil read it like any other vowel.
+const ReducedI = "ə"
+
+// ReduceUnstressedI spells the unstressed ì as a schwa, like it's often heard in fast speech, e.g. "tì.ke.*nong" becomes
+// "tə.ke.*nong". It looks at the stress of the whole word, so it's a WindowFilter.
+//
+// StrengthLight only reduces ì that is two or more syllables away from the stress, StrengthMedium also reduces it
+// right after the stress, and StrengthStrong reduces all of them.
+func ReduceUnstressedI(strength Strength) WindowFilter {
+	return func(c *Cursor) {
+		if c.Stressed() || !strings.ContainsAny(c.Syllable(), "ìÌ") {
+			return
+		}
+
+		if stress := c.Match().Stress; stress >= 0 {
+			distance := c.SyllableIndex - stress
+			if strength < StrengthStrong && distance == -1 {
+				return
+			}
+			if strength < StrengthMedium && distance == 1 {
+				return
+			}
+		}
+
+		c.Set(reducedIReplacer.Replace(c.Syllable()))
+	}
+}
+
+// DropGlottalStopsBetweenIdenticalVowels drops the ' at the start of an unstressed syllable if the vowels around it
+// are the same, e.g. "*to.'o" becomes "*to.o". This is like ReefDropGlottalStopsBetweenVowels, but for Forest speech, so
+// it is more careful. It is only done within a word.
+//
+// StrengthLight only drops it between identical vowels, StrengthMedium also between ì and i, and ä and e, and
+// StrengthStrong between any two vowels.
+func DropGlottalStopsBetweenIdenticalVowels(strength Strength) Filter {
+	return func(curr, next *FilterTarget) (*string, *string) {
+		if next == nil || next.SyllableIndex == 0 || next.Stressed || !strings.HasPrefix(next.Syllable, "'") {
+			return nil, nil
+		}
+
+		clr, _ := utf8.DecodeLastRuneInString(curr.Syllable)
+		nfr, _ := utf8.DecodeRuneInString(next.Syllable[len("'"):])
+		if !similarVowels(clr, nfr, strength) {
+			return nil, nil
+		}
+
+		newNext := next.Syllable[len("'"):]
+		return nil, &newNext
+	}
+}
+
+// CollapseIdenticalVowelsAcrossWords merges a word ending in a vowel with the next if it starts with the same vowel,
+// e.g. "ma aynga" becomes "maynga". Only the vowel of the second word is kept.
+//
+// StrengthLight only does it after words with one syllable, StrengthMedium also after unstressed syllables, and
+// StrengthStrong after any syllable and across commas.
+func CollapseIdenticalVowelsAcrossWords(strength Strength) Filter {
+	return func(curr, next *FilterTarget) (*string, *string) {
+		if next == nil || next.SyllableIndex != 0 || curr.After == "" {
+			return nil, nil
+		}
+
+		breaks := " "
+		if strength >= StrengthStrong {
+			breaks = " ,"
+		}
+		if strings.Trim(curr.After, breaks) != "" || strings.Count(curr.After, ",") > 1 {
+			return nil, nil
+		}
+
+		oneSyllable := curr.SyllableIndex == 0
+		switch {
+		case strength < StrengthMedium && !oneSyllable:
+			return nil, nil
+		case strength < StrengthStrong && !oneSyllable && curr.Stressed:
+			return nil, nil
+		}
+
+		clr, clrLen := utf8.DecodeLastRuneInString(curr.Syllable)
+		nfr, _ := utf8.DecodeRuneInString(next.Syllable)
+		if !slices.Contains(vowels, unicode.ToLower(clr)) || unicode.ToLower(clr) != unicode.ToLower(nfr) {
+			return nil, nil
+		}
+
+		currChange := ""
+		nextChange := curr.Syllable[:len(curr.Syllable)-clrLen] + next.Syllable
+		return &currChange, &nextChange
+	}
+}
+
+func similarVowels(a, b rune, strength Strength) bool {
+	a, b = unicode.ToLower(a), unicode.ToLower(b)
+	if !slices.Contains(vowels, a) || !slices.Contains(vowels, b) {
+		return false
+	}
+
+	switch {
+	case a == b:
+		return true
+	case strength >= StrengthStrong:
+		return true
+	case strength >= StrengthMedium:
+		return slices.ContainsFunc(similarVowelPairs, func(pair [2]rune) bool {
+			return (pair[0] == a && pair[1] == b) || (pair[0] == b && pair[1] == a)
+		})
+	default:
+		return false
+	}
+}
+
+var reducedIReplacer = strings.NewReplacer("ì", ReducedI, "Ì", "Ə")
+var similarVowelPairs = [][2]rune{{'ì', 'i'}, {'ä', 'e'}}
@@ -0,0 +1,178 @@
+package litxapfilter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReduceUnstressedI(t *testing.T) {
+	table := []struct {
+		input    string
+		strength Strength
+		expected string
+	}{
+		{"Tìkenong", StrengthLight, "Təkenong"},
+		{"tìkenong", StrengthLight, "təkenong"},
+		{"tsmukìri", StrengthLight, "tsmukìri"},
+		{"tsmukìri", StrengthMedium, "tsmukəri"},
+		{"tìkangkem", StrengthMedium, "tìkangkem"},
+		{"tìkangkem", StrengthStrong, "təkangkem"},
+		{"kaltxì", StrengthStrong, "kaltxì"},
+		{"sì", StrengthStrong, "sì"},
+	}
+
+	for _, row := range table {
+		t.Run(fmt.Sprintf("%s(%d)", row.input, row.strength), func(t *testing.T) {
+			line, err := litxap.RunLine(row.input, dummyDictionary)
+			require.NoError(t, err)
+
+			res := ApplyFilters(line, ReduceUnstressedI(row.strength))
+			assert.Equal(t, row.expected, res[0].Raw)
+			assert.Equal(t, line[0].Matches[0].Stress, res[0].Matches[0].Stress)
+		})
+	}
+}
+
+func TestReduceUnstressedI_Transcriptions(t *testing.T) {
+	line, err := litxap.RunLine("Tìkenong", dummyDictionary)
+	require.NoError(t, err)
+	res := ApplyFilters(line, ReduceUnstressedI(StrengthLight))
+
+	ipa := res.IPAWithOptions(nil, litxap.IPAOptions{SyllableDelimiter: "."})
+	require.NoError(t, ipa.Err())
+	assert.Equal(t, "tə.kɛ.ˈnoŋ", ipa.String())
+
+	phonemes, err := res.Phonemes(nil, litxap.PhonemeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "@", phonemes[1].Symbol)
+
+	assert.Contains(t, res.SSML(nil, litxap.SSMLOptions{}), `ph="təkɛˈnoŋ">Təkenong<`)
+}
+
+func TestDropGlottalStopsBetweenIdenticalVowels(t *testing.T) {
+	table := []struct {
+		currSyllable string
+		nextSyllable string
+		strength     Strength
+		nextChange   string
+	}{
+		{"to", "'o", StrengthLight, "o"},
+		{"rä", "'Ä", StrengthLight, "Ä"},
+		{"rä", "'e", StrengthLight, ""},
+		{"rä", "'e", StrengthMedium, "e"},
+		{"tì", "'i", StrengthMedium, "i"},
+		{"ve", "'o", StrengthMedium, ""},
+		{"ve", "'o", StrengthStrong, "o"},
+		{"to", "*'o", StrengthStrong, ""},
+		{"ep", "'ep", StrengthStrong, ""},
+		{"to", "-'o", StrengthStrong, ""},
+		{"to", "", StrengthStrong, ""},
+		{"to", "o", StrengthStrong, ""},
+	}
+
+	for _, row := range table {
+		t.Run(fmt.Sprintf("%s.%s(%d)", row.currSyllable, row.nextSyllable, row.strength), func(t *testing.T) {
+			curr := &FilterTarget{Syllable: row.currSyllable}
+			var next *FilterTarget
+			if row.nextSyllable != "" {
+				next = &FilterTarget{Syllable: row.nextSyllable, SyllableIndex: 1}
+				if strings.HasPrefix(next.Syllable, "*") {
+					next.Syllable = strings.TrimPrefix(next.Syllable, "*")
+					next.Stressed = true
+				}
+				if strings.HasPrefix(next.Syllable, "-") {
+					next.Syllable = strings.TrimPrefix(next.Syllable, "-")
+					next.SyllableIndex = 0
+				}
+			}
+
+			currChange, nextChange := DropGlottalStopsBetweenIdenticalVowels(row.strength)(curr, next)
+			assert.Nil(t, currChange)
+			if row.nextChange == "" {
+				assert.Nil(t, nextChange)
+			} else {
+				if assert.NotNil(t, nextChange) {
+					assert.Equal(t, row.nextChange, *nextChange)
+				}
+			}
+		})
+	}
+}
+
+func TestCollapseIdenticalVowelsAcrossWords(t *testing.T) {
+	table := []struct {
+		curr, after, next string
+		strength          Strength
+		changeNext        string
+	}{
+		{"ma", " ", "ay", StrengthLight, "may"},
+		{"Ma", " ", "Ay", StrengthLight, "MAy"},
+		{"-te", " ", "Ey", StrengthLight, ""},
+		{"-te", " ", "Ey", StrengthMedium, "tEy"},
+		{"-*te", " ", "Ey", StrengthMedium, ""},
+		{"-*te", " ", "Ey", StrengthStrong, "tEy"},
+		{"ma", ", ", "ay", StrengthMedium, ""},
+		{"ma", ", ", "ay", StrengthStrong, "may"},
+		{"ma", ". ", "ay", StrengthStrong, ""},
+		{"ma", " ", "ey", StrengthStrong, ""},
+		{"ma", " ", "-a", StrengthStrong, ""},
+		{"ma", "", "a", StrengthStrong, ""},
+		{"pay", " ", "ay", StrengthStrong, ""},
+		{"ma", " ", "", StrengthStrong, ""},
+	}
+
+	for _, row := range table {
+		t.Run(fmt.Sprintf("%s%s%s(%d)", row.curr, row.after, row.next, row.strength), func(t *testing.T) {
+			curr := &FilterTarget{Syllable: row.curr, After: row.after}
+			var next *FilterTarget
+			if row.next != "" {
+				next = &FilterTarget{Syllable: row.next}
+			}
+
+			if strings.HasPrefix(curr.Syllable, "-") {
+				curr.Syllable = strings.TrimPrefix(curr.Syllable, "-")
+				curr.SyllableIndex = 1
+			}
+			if strings.HasPrefix(curr.Syllable, "*") {
+				curr.Syllable = strings.TrimPrefix(curr.Syllable, "*")
+				curr.Stressed = true
+			}
+			if next != nil && strings.HasPrefix(next.Syllable, "-") {
+				next.Syllable = strings.TrimPrefix(next.Syllable, "-")
+				next.SyllableIndex = 1
+			}
+
+			changeCurr, changeNext := CollapseIdenticalVowelsAcrossWords(row.strength)(curr, next)
+
+			if row.changeNext == "" {
+				assert.Nil(t, changeCurr)
+				assert.Nil(t, changeNext)
+			} else {
+				if assert.NotNil(t, changeCurr) {
+					assert.Equal(t, "", *changeCurr)
+				}
+				if assert.NotNil(t, changeNext) {
+					assert.Equal(t, row.changeNext, *changeNext)
+				}
+			}
+		})
+	}
+}
+
+func TestCollapseIdenticalVowelsAcrossWords_Line(t *testing.T) {
+	line, err := litxap.RunLine("Ma aynga!", dummyDictionary)
+	require.NoError(t, err)
+
+	res := ApplyFilter(line, CollapseIdenticalVowelsAcrossWords(StrengthLight))
+	assert.Equal(t, litxap.Line{
+		{Raw: "Maynga", Original: "Ma aynga", IsWord: true, Matches: []litxap.LinePartMatch{
+			{Syllables: []string{"May", "nga"}, Stress: 0, Entry: dummyDictionary.entry("aynga", 0)},
+		}},
+		{Raw: "!"},
+	}, res)
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gissleh/litxap"
+	"github.com/gissleh/litxap/litxaputil"
 )
 
 // A Filter takes in a sliding window of two syllables and return modification to
@@ -21,11 +22,32 @@ type FilterTarget struct {
 	Entry         *litxap.Entry
 }
 
+// Structure parses the syllable into its parts with litxaputil.ParseSyllable, keeping the casing.
+func (t *FilterTarget) Structure() (litxaputil.Syllable, bool) {
+	return litxaputil.ParseSyllable(t.Syllable)
+}
+
+// AnyFilter is either a Filter or a WindowFilter, so that both kinds can be run together. A function with the
+// signature of a Filter must be converted to it first, e.g. Filter(NasalAssimilation).
+type AnyFilter interface {
+	applyWithReport(line litxap.Line, name string, report *FilterReport) litxap.Line
+}
+
+func (f Filter) applyWithReport(line litxap.Line, name string, report *FilterReport) litxap.Line {
+	return ApplyFilterWithReport(line, f, name, report)
+}
+
+func (f WindowFilter) applyWithReport(line litxap.Line, name string, report *FilterReport) litxap.Line {
+	return ApplyWindowFilterWithReport(line, f, name, report)
+}
+
 // ApplyFilters is just a wrapper for running one filter after another. They'll each make a full pass
-// so the next filter will be dealing with the output of the previous filter.
-func ApplyFilters(line litxap.Line, filters ...Filter) litxap.Line {
+// so the next filter will be dealing with the output of the previous filter. All matches are filtered,
+// and the changes one of them makes to the next word apply to all its matches, so use
+// ApplyFiltersSelected if that matters.
+func ApplyFilters(line litxap.Line, filters ...AnyFilter) litxap.Line {
 	for _, filter := range filters {
-		line = ApplyFilter(line, filter)
+		line = filter.applyWithReport(line, "", nil)
 	}
 
 	return line
@@ -34,13 +56,48 @@ func ApplyFilters(line litxap.Line, filters ...Filter) litxap.Line {
 // ApplyFilter runs the filtering logic. It will not copy any more than necessary, up to returning the
 // passed litxap.Line if the filter ends up changing nothing.
 func ApplyFilter(line litxap.Line, filter Filter) litxap.Line {
+	return ApplyFilterWithReport(line, filter, "", nil)
+}
+
+// ApplyFilterWithReport is ApplyFilter, but it adds the changes to the report under the filter name and marks the
+// syllables in the matches' ChangedSyllables. The changes that are already in the report are moved along with the
+// syllables they point to. The report can be nil.
+func ApplyFilterWithReport(line litxap.Line, filter Filter, name string, report *FilterReport) litxap.Line {
+	return applyFilterWithReport(line, filter, nil, name, report)
+}
+
+// ApplyWindowFilterWithReport is ApplyFilterWithReport for a WindowFilter. The filter is called with a Cursor for
+// every syllable that has not been removed, in order.
+func ApplyWindowFilterWithReport(line litxap.Line, filter WindowFilter, name string, report *FilterReport) litxap.Line {
+	return applyFilterWithReport(line, nil, filter, name, report)
+}
+
+// applyFilterWithReport runs either filter or window, whichever is not nil.
+func applyFilterWithReport(line litxap.Line, filter Filter, window WindowFilter, name string, report *FilterReport) litxap.Line {
+	if report != nil {
+		report.start(line)
+	}
+
 	newLine := line
+	var changes []FilterChange
 
 	copiedLine := false
 	copiedParts := make(map[int]bool)
 	copiedSyllables := make(map[[2]int]bool)
 
 	setSyllable := func(pi, mi, si int, value string) {
+		before := newLine[pi].Matches[mi].Syllables[si]
+		if report != nil && value != before {
+			changes = append(changes, FilterChange{
+				Filter:        name,
+				PartIndex:     pi,
+				MatchIndex:    mi,
+				SyllableIndex: si,
+				Before:        before,
+				After:         value,
+			})
+		}
+
 		if !copiedSyllables[[2]int{pi, mi}] {
 			if !copiedParts[pi] {
 				if !copiedLine {
@@ -65,6 +122,14 @@ func ApplyFilter(line litxap.Line, filter Filter) litxap.Line {
 
 		for mi := range newLine[pi].Matches {
 			for si, syllable := range newLine[pi].Matches[mi].Syllables {
+				if window != nil {
+					// The filter may have removed it already through another cursor.
+					if newLine[pi].Matches[mi].Syllables[si] != "" {
+						window(&Cursor{PartIndex: pi, MatchIndex: mi, SyllableIndex: si, line: &newLine, set: setSyllable})
+					}
+
+					continue
+				}
 				if syllable == "" {
 					continue
 				}
@@ -153,37 +218,61 @@ func ApplyFilter(line litxap.Line, filter Filter) litxap.Line {
 
 	// Remove cleared syllables
 	if copiedLine {
+		if report != nil {
+			for _, change := range changes {
+				match := &newLine[change.PartIndex].Matches[change.MatchIndex]
+				if !slices.Contains(match.ChangedSyllables, change.SyllableIndex) {
+					match.ChangedSyllables = append(slices.Clone(match.ChangedSyllables), change.SyllableIndex)
+					slices.Sort(match.ChangedSyllables)
+				}
+			}
+
+			report.Changes = append(report.Changes, changes...)
+		}
+
+		// These keep track of where things moved, so the report can be updated.
+		syllableMoves := make(map[[2]int][]int)
+		matchMoves := make(map[int][]int)
+		deletedParts := make(map[int]bool)
+
 		matchDeleteList := make([]int, 0)
-		partDeleteList := make([]int, 0)
 
 		for pi, part := range newLine {
 			if !copiedParts[pi] {
 				continue
 			}
 
+			matchMoves[pi] = make([]int, len(part.Matches))
 			for mi, match := range part.Matches {
+				matchMoves[pi][mi] = mi - len(matchDeleteList)
 				if !copiedSyllables[[2]int{pi, mi}] {
 					continue
 				}
 
+				moves := make([]int, len(match.Syllables))
 				n := 0
 				for si, syllable := range match.Syllables {
 					if syllable != "" {
+						moves[si] = n
 						match.Syllables[n] = syllable
 						n += 1
-					} else if match.Stress >= si {
-						// Omitted syllable left of stress should move it back.
-						newLine[pi].Matches[mi].Stress -= 1
+					} else {
+						moves[si] = -1
+						if match.Stress >= si {
+							// Omitted syllable left of stress should move it back.
+							newLine[pi].Matches[mi].Stress -= 1
+						}
 					}
 				}
-				newLine[pi].Matches[mi].Syllables = match.Syllables[:n]
+				syllableMoves[[2]int{pi, mi}] = moves
 
-				if mi == 0 {
-					newLine[pi].Raw = strings.Join(newLine[pi].Matches[mi].Syllables, "")
-				}
+				newLine[pi].Matches[mi].Syllables = match.Syllables[:n]
+				newLine[pi].Matches[mi].SecondaryStress = moveIndices(match.SecondaryStress, moves)
+				newLine[pi].Matches[mi].ChangedSyllables = moveIndices(newLine[pi].Matches[mi].ChangedSyllables, moves)
 
 				if len(newLine[pi].Matches[mi].Syllables) == 0 {
 					matchDeleteList = append(matchDeleteList, mi-len(matchDeleteList))
+					matchMoves[pi][mi] = -1
 				}
 			}
 
@@ -193,21 +282,104 @@ func ApplyFilter(line litxap.Line, filter Filter) litxap.Line {
 			matchDeleteList = matchDeleteList[:0]
 
 			if len(newLine[pi].Matches) == 0 {
-				partDeleteList = append(partDeleteList, pi-len(partDeleteList))
-				if pi < len(newLine)-1 {
-					partDeleteList = append(partDeleteList, (pi+1)-len(partDeleteList))
+				removeWord(newLine, pi, deletedParts)
+				continue
+			}
+
+			// The Raw follows the first match, which may have changed or be another match than before.
+			first := slices.Index(matchMoves[pi], 0)
+			if first != 0 || copiedSyllables[[2]int{pi, first}] {
+				raw := strings.Join(newLine[pi].Matches[0].Syllables, "")
+				if raw != newLine[pi].Raw && newLine[pi].Original == "" {
+					newLine[pi].Original = newLine[pi].Raw
+				}
+
+				newLine[pi].Raw = raw
+			}
+		}
+
+		if len(deletedParts) > 0 {
+			keptParts := make(litxap.Line, 0, len(newLine)-len(deletedParts))
+			for pi, part := range newLine {
+				if !deletedParts[pi] {
+					keptParts = append(keptParts, part)
 				}
 			}
+
+			newLine = keptParts
 		}
 
-		for _, pi := range partDeleteList {
-			newLine = append(newLine[:pi], newLine[pi+1:]...)
+		if report != nil {
+			report.move(syllableMoves, matchMoves, deletedParts)
 		}
 	}
 
 	return newLine
 }
 
+// removeWord marks a word that has lost all its matches for removal, along with one of the separators around it so
+// the words on either side don't end up with two of them. A separator that's only whitespace goes first, then the one
+// before the word, since the one after may end the sentence. The removed text is kept in the Original of the word
+// next to it, so that it is not lost for litxap.FormatOriginal.
+func removeWord(line litxap.Line, pi int, deleted map[int]bool) {
+	deleted[pi] = true
+
+	text := line[pi].Original
+	if text == "" {
+		text = line[pi].Raw
+	}
+
+	hasBefore := pi > 0 && !line[pi-1].IsWord && !deleted[pi-1]
+	hasAfter := pi < len(line)-1 && !line[pi+1].IsWord
+	switch {
+	case hasAfter && strings.TrimSpace(line[pi+1].Raw) == "":
+		deleted[pi+1] = true
+		carryOriginal(line, pi, text+line[pi+1].Raw, deleted, true)
+	case hasBefore:
+		deleted[pi-1] = true
+		carryOriginal(line, pi, line[pi-1].Raw+text, deleted, false)
+	case hasAfter:
+		deleted[pi+1] = true
+		carryOriginal(line, pi, text+line[pi+1].Raw, deleted, true)
+	default:
+		carryOriginal(line, pi, text, deleted, true)
+	}
+}
+
+// carryOriginal adds the original text of removed parts to the Original of the next word if forward is set, or else
+// the previous. It goes the other way if there is no word in that direction.
+func carryOriginal(line litxap.Line, pi int, original string, deleted map[int]bool, forward bool) {
+	next := -1
+	for j := pi + 1; j < len(line); j++ {
+		if line[j].IsWord && !deleted[j] {
+			next = j
+			break
+		}
+	}
+
+	prev := -1
+	for j := pi - 1; j >= 0; j-- {
+		if line[j].IsWord && !deleted[j] {
+			prev = j
+			break
+		}
+	}
+
+	if next != -1 && (forward || prev == -1) {
+		if line[next].Original == "" {
+			line[next].Original = line[next].Raw
+		}
+
+		line[next].Original = original + line[next].Original
+	} else if prev != -1 {
+		if line[prev].Original == "" {
+			line[prev].Original = line[prev].Raw
+		}
+
+		line[prev].Original += original
+	}
+}
+
 func nextPartAfter(line litxap.Line, i int) int {
 	for j := i + 1; j < len(line); j++ {
 		if line[j].IsWord {
@@ -16,189 +16,229 @@ func TestLine_ApplyFilter(t *testing.T) {
 	table := []struct {
 		input    string
 		expected litxap.Line
-		filters  []Filter
+		filters  []AnyFilter
 	}{
 		{
 			input: "Kaltxì, ma kxitx.",
 			expected: litxap.Line{
-				{Raw: "Kaltì", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"Kal", "tì"}, 1, dummyDictionary.entry("kaltxì", 0), false},
+				{Raw: "Kaltì", Original: "Kaltxì", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"Kal", "tì"}, Stress: 1, Entry: dummyDictionary.entry("kaltxì", 0)},
 				}},
 				{Raw: ", "},
 				{Raw: "ma", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"ma"}, 0, dummyDictionary.entry("ma", 0), false},
+					{Syllables: []string{"ma"}, Stress: 0, Entry: dummyDictionary.entry("ma", 0)},
 				}},
 				{Raw: " "},
-				{Raw: "kit", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"kit"}, 0, dummyDictionary.entry("kxitx", 0), false},
+				{Raw: "kit", Original: "kxitx", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"kit"}, Stress: 0, Entry: dummyDictionary.entry("kxitx", 0)},
 				}},
 				{Raw: "."},
 			},
-			filters: []Filter{dummyFilterEjectiveHater},
+			filters: []AnyFilter{dummyFilterEjectiveHater},
 		},
 		{
 			input: "Oel ngati kameie, ma RumaUt.",
 			expected: litxap.Line{
-				{Raw: "Wel", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"Wel"}, 0, dummyDictionary.entry("oel", 0), false},
+				{Raw: "Wel", Original: "Oel", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"Wel"}, Stress: 0, Entry: dummyDictionary.entry("oel", 0)},
 				}},
 				{Raw: " "},
 				{Raw: "ngati", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"nga", "ti"}, 0, dummyDictionary.entry("ngati", 0), false},
+					{Syllables: []string{"nga", "ti"}, Stress: 0, Entry: dummyDictionary.entry("ngati", 0)},
 				}},
 				{Raw: " "},
-				{Raw: "kameye", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"ka", "me", "ye"}, 0, dummyDictionary.entry("kameie", 0), false},
+				{Raw: "kameye", Original: "kameie", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"ka", "me", "ye"}, Stress: 0, Entry: dummyDictionary.entry("kameie", 0)},
 				}},
 				{Raw: ", "},
 				{Raw: "ma", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"ma"}, 0, dummyDictionary.entry("ma", 0), false},
+					{Syllables: []string{"ma"}, Stress: 0, Entry: dummyDictionary.entry("ma", 0)},
 				}},
 				{Raw: " "},
-				{Raw: "RumaWt", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"Ru", "maWt"}, 0, dummyDictionary.entry("rumaut", 0), false},
+				{Raw: "RumaWt", Original: "RumaUt", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"Ru", "maWt"}, Stress: 0, Entry: dummyDictionary.entry("rumaut", 0)},
 				}},
 				{Raw: "."},
 			},
-			filters: []Filter{
-				DiphthongFromWeakVowel,
-				ReanalyzeDiphthongs,
-				SpellOeAsWe,
+			filters: []AnyFilter{
+				Filter(DiphthongFromWeakVowel),
+				Filter(ReanalyzeDiphthongs),
+				Filter(SpellOeAsWe),
 			},
 		},
 		{
 			input: "fmetokyu fmeretok.",
 			expected: litxap.Line{
 				{Raw: "fmetokyu", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"fme", "tok", "yu"}, 0, dummyDictionary.entry("fmetokyu", 0), false},
+					{Syllables: []string{"fme", "tok", "yu"}, Stress: 0, Entry: dummyDictionary.entry("fmetokyu", 0)},
 				}},
 				{Raw: " "},
-				{Raw: "retok", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"re", "tok"}, 0, dummyDictionary.entry("fmeretok", 0), false},
+				{Raw: "retok", Original: "fmeretok", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"re", "tok"}, Stress: 0, Entry: dummyDictionary.entry("fmeretok", 0)},
 				}},
 				{Raw: "."},
 			},
-			filters: []Filter{dummyFilterNextEliminator("fme")},
+			filters: []AnyFilter{dummyFilterNextEliminator("fme")},
 		},
 
 		{
 			input: "Oe tìng nari.",
 			expected: litxap.Line{
 				{Raw: "Oe", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"O", "e"}, 0, dummyDictionary.entry("oe", 0), false},
+					{Syllables: []string{"O", "e"}, Stress: 0, Entry: dummyDictionary.entry("oe", 0)},
 				}},
 				{Raw: " "},
-				{Raw: "tì", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"tì"}, 0, dummyDictionary.entry("tìng", 0), false},
+				{Raw: "tì", Original: "tìng", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"tì"}, Stress: 0, Entry: dummyDictionary.entry("tìng", 0)},
 				}},
 				{Raw: " "},
 				{Raw: "nari", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"na", "ri"}, 0, dummyDictionary.entry("nari", 0), false},
+					{Syllables: []string{"na", "ri"}, Stress: 0, Entry: dummyDictionary.entry("nari", 0)},
 				}},
 				{Raw: "."},
 			},
-			filters: []Filter{NasalAssimilation},
+			filters: []AnyFilter{Filter(NasalAssimilation)},
 		},
 		{
 			input: "Fmetan mal lu!",
 			expected: litxap.Line{
-				{Raw: "Fmeta", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"Fme", "ta"}, 0, dummyDictionary.entry("fmetan", 0), false},
-					{[]string{"Fme", "ta"}, 1, dummyDictionary.entry("fmetan", 1), false},
+				{Raw: "Fmeta", Original: "Fmetan", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"Fme", "ta"}, Stress: 0, Entry: dummyDictionary.entry("fmetan", 0)},
+					{Syllables: []string{"Fme", "ta"}, Stress: 1, Entry: dummyDictionary.entry("fmetan", 1)},
 				}},
 				{Raw: " "},
 				{Raw: "mal", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"mal"}, 0, dummyDictionary.entry("mal", 0), false},
+					{Syllables: []string{"mal"}, Stress: 0, Entry: dummyDictionary.entry("mal", 0)},
 				}},
 				{Raw: " "},
 				{Raw: "lu", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"lu"}, 0, dummyDictionary.entry("lu", 0), false},
+					{Syllables: []string{"lu"}, Stress: 0, Entry: dummyDictionary.entry("lu", 0)},
 				}},
 				{Raw: "!"},
 			},
-			filters: []Filter{NasalAssimilation},
+			filters: []AnyFilter{Filter(NasalAssimilation)},
 		},
 		{
 			input: "Fmetan?",
 			expected: litxap.Line{
 				{Raw: "Fmetan", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"Fme", "tan"}, 0, dummyDictionary.entry("fmetan", 0), false},
-					{[]string{"tan"}, 0, dummyDictionary.entry("fmetan", 1), false},
+					{Syllables: []string{"Fme", "tan"}, Stress: 0, Entry: dummyDictionary.entry("fmetan", 0)},
+					{Syllables: []string{"tan"}, Stress: 0, Entry: dummyDictionary.entry("fmetan", 1)},
 				}},
 				{Raw: "?"},
 			},
-			filters: []Filter{NasalAssimilation, dummyFilterCurrEliminatorAtIndex(1, "Fme")},
+			filters: []AnyFilter{Filter(NasalAssimilation), dummyFilterCurrEliminatorAtIndex(1, "Fme")},
+		},
+		{
+			input: "Fmetan!",
+			expected: litxap.Line{
+				{Raw: "Fmetan", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"Fme", "tan"}, Stress: 1, Entry: dummyDictionary.entry("fmetan", 1)},
+				}},
+				{Raw: "!"},
+			},
+			filters: []AnyFilter{dummyFilterCurrEliminatorAtIndex(0, "Fme", "tan")},
+		},
+		{
+			input: "Kaltxì, ma.",
+			expected: litxap.Line{
+				{Raw: "Kaltxì", Original: "Kaltxì, ma", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"Kal", "txì"}, Stress: 1, Entry: dummyDictionary.entry("kaltxì", 0)},
+				}},
+				{Raw: "."},
+			},
+			filters: []AnyFilter{dummyFilterCurrEliminatorAtIndex(0, "ma")},
+		},
+		{
+			input: "Ma, kaltxì.",
+			expected: litxap.Line{
+				{Raw: "kaltxì", Original: "Ma, kaltxì", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"kal", "txì"}, Stress: 1, Entry: dummyDictionary.entry("kaltxì", 0)},
+				}},
+				{Raw: "."},
+			},
+			filters: []AnyFilter{dummyFilterCurrEliminatorAtIndex(0, "Ma")},
 		},
 		{
 			input: "Sänume säpeyki.",
 			expected: litxap.Line{
-				{Raw: "Snume", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"Snu", "me"}, 0, dummyDictionary.entry("sänume", 0), false},
+				{Raw: "Snume", Original: "Sänume", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"Snu", "me"}, Stress: 0, Entry: dummyDictionary.entry("sänume", 0)},
 				}},
 				{Raw: " "},
-				{Raw: "speyki", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"spey", "ki"}, 1, dummyDictionary.entry("säpeyki", 0), false},
+				{Raw: "speyki", Original: "säpeyki", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"spey", "ki"}, Stress: 1, Entry: dummyDictionary.entry("säpeyki", 0)},
 				}},
 				{Raw: "."},
 			},
-			filters: []Filter{SaeRemover},
+			filters: []AnyFilter{Filter(SaeRemover)},
 		},
 		{
 			input: "Pori fpomtoKX sì fpomroN yo'.",
 			expected: litxap.Line{
 				{Raw: "Pori", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"Po", "ri"}, 0, dummyDictionary.entry("pori", 0), false},
+					{Syllables: []string{"Po", "ri"}, Stress: 0, Entry: dummyDictionary.entry("pori", 0)},
 				}},
 				{Raw: " "},
-				{Raw: "fpomtoK", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"fpom", "toK"}, 1, dummyDictionary.entry("fpomtokx", 0), false},
+				{Raw: "fpomtoK", Original: "fpomtoKX", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"fpom", "toK"}, Stress: 1, Entry: dummyDictionary.entry("fpomtokx", 0)},
 				}},
 				{Raw: " "},
 				{Raw: "sì", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"sì"}, 0, dummyDictionary.entry("sì", 0), false},
+					{Syllables: []string{"sì"}, Stress: 0, Entry: dummyDictionary.entry("sì", 0)},
 				}},
 				{Raw: " "},
 				{Raw: "fpomroN", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"fpom", "roN"}, 1, dummyDictionary.entry("fpomron", 0), false},
+					{Syllables: []string{"fpom", "roN"}, Stress: 1, Entry: dummyDictionary.entry("fpomron", 0)},
 				}},
 				{Raw: " "},
 				{Raw: "yo'", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"yo'"}, 0, dummyDictionary.entry("yo'", 0), false},
+					{Syllables: []string{"yo'"}, Stress: 0, Entry: dummyDictionary.entry("yo'", 0)},
 				}},
 				{Raw: "."},
 			},
-			filters: []Filter{
-				NasalAssimilation,
-				DemoteEjectivesBeforeConsonants,
+			filters: []AnyFilter{
+				Filter(NasalAssimilation),
+				Filter(DemoteEjectivesBeforeConsonants),
+			},
+		},
+		{
+			input: "Fmetokmungwrr!",
+			expected: litxap.Line{
+				{Raw: "Fmemungwrr", Original: "Fmetokmungwrr", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"Fme", "mung", "wrr"}, Stress: 0, SecondaryStress: []int{1}, Entry: dummyDictionary.entry("fmetokmungwrr", 0)},
+				}},
+				{Raw: "!"},
 			},
+			filters: []AnyFilter{dummyFilterNextEliminator("tok")},
 		},
 		{
 			input: "Sunu oer aymauti, sì ayspxam nìayfo!",
 			expected: litxap.Line{
 				{Raw: "Sunu", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"Su", "nu"}, 0, dummyDictionary.entry("sunu", 0), false},
+					{Syllables: []string{"Su", "nu"}, Stress: 0, Entry: dummyDictionary.entry("sunu", 0)},
 				}},
 				{Raw: " "},
 				{Raw: "oer", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"oer"}, 0, dummyDictionary.entry("oer", 0), false},
+					{Syllables: []string{"oer"}, Stress: 0, Entry: dummyDictionary.entry("oer", 0)},
 				}},
 				{Raw: " "},
 				{Raw: "aymauti", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"ay", "ma", "u", "ti"}, 1, dummyDictionary.entry("aymauti", 0), false},
+					{Syllables: []string{"ay", "ma", "u", "ti"}, Stress: 1, Entry: dummyDictionary.entry("aymauti", 0)},
 				}},
 				{Raw: ", "},
-				{Raw: "sayspxa", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"say", "spxa"}, 1, dummyDictionary.entry("ayspxam", 0), false},
+				{Raw: "sayspxa", Original: "sì ayspxam", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"say", "spxa"}, Stress: 1, Entry: dummyDictionary.entry("ayspxam", 0)},
 				}},
 				{Raw: " "},
-				{Raw: "nayfo", IsWord: true, Matches: []litxap.LinePartMatch{
-					{[]string{"nay", "fo"}, 1, dummyDictionary.entry("nìayfo", 0), false},
+				{Raw: "nayfo", Original: "nìayfo", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"nay", "fo"}, Stress: 1, Entry: dummyDictionary.entry("nìayfo", 0)},
 				}},
 				{Raw: "!"},
 			},
-			filters: []Filter{
-				ElideMiSiNiBeforeAy,
-				NasalAssimilation,
+			filters: []AnyFilter{
+				Filter(ElideMiSiNiBeforeAy),
+				Filter(NasalAssimilation),
 			},
 		},
 	}
@@ -229,6 +269,60 @@ func TestLine_ApplyFilter_NoChange(t *testing.T) {
 	assert.Same(t, unsafe.SliceData(line), unsafe.SliceData(line2))
 }
 
+func TestLine_FormatWithMode(t *testing.T) {
+	table := []struct {
+		input      string
+		filters    []AnyFilter
+		selections map[int]int
+		defaults   string
+		original   string
+		phonetic   string
+	}{
+		{
+			input:    "Kiyevame ulte Eywa ngahu.",
+			filters:  []AnyFilter{Filter(ElideUnstressedEWordEndings)},
+			defaults: "Kiye__va__ mul __tEy__wa __nga__hu.",
+			original: "Kiye__va__me ulte __Ey__wa __nga__hu.",
+			phonetic: "Kiye__va__ mul __tEy__wa __nga__hu.",
+		},
+		{
+			input:    "Kaltxì, ma kxitx.",
+			filters:  []AnyFilter{dummyFilterEjectiveHater},
+			defaults: "Kal__tì__, ma kit.",
+			original: "Kal__txì__, ma kxitx.",
+			phonetic: "Kal__tì__, ma kit.",
+		},
+		{
+			input:      "Fmetan?",
+			filters:    []AnyFilter{Filter(NasalAssimilation), dummyFilterCurrEliminatorAtIndex(1, "Fme")},
+			selections: map[int]int{0: 1},
+			defaults:   "Fmetan?",
+			original:   "Fmetan?",
+			phonetic:   "tan?",
+		},
+		{
+			input:    "Sunu oer aymauti, sì ayspxam nìayfo!",
+			filters:  []AnyFilter{Filter(ElideMiSiNiBeforeAy)},
+			defaults: "__Su__nu oer ay__ma__uti, say__spxam__ nay__fo__!",
+			original: "__Su__nu oer ay__ma__uti, sì ay__spxam__ nìay__fo__!",
+			phonetic: "__Su__nu oer ay__ma__uti, say__spxam__ nay__fo__!",
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			line, err := litxap.RunLine(row.input, dummyDictionary)
+			require.NoError(t, err)
+
+			line = ApplyFilters(line, row.filters...)
+			f := litxapformats.DiscordMarkdown()
+			assert.Equal(t, row.defaults, line.FormatWithMode(f, row.selections, litxap.FormatDefault))
+			assert.Equal(t, row.original, line.FormatWithMode(f, row.selections, litxap.FormatOriginal))
+			assert.Equal(t, row.phonetic, line.FormatWithMode(f, row.selections, litxap.FormatPhonetic))
+		})
+	}
+}
+
 var dummyFilterEjectiveHater Filter = func(curr, next *FilterTarget) (currChange *string, nextChange *string) {
 	if strings.ContainsRune(curr.Syllable, 'x') {
 		ejectiveLess := strings.ReplaceAll(curr.Syllable, "x", "")
@@ -280,32 +374,52 @@ func (dictionary DummyDictionary) entry(word string, i int) litxap.Entry {
 }
 
 var dummyDictionary = DummyDictionary{
-	"kaltxì":   "kal.*txì",
-	"ma":       "ma",
-	"fmetokyu": "fme.tok: -yu",
-	"fmeretok": "fm·e.t·ok: <er>",
-	"lu":       "lu",
-	"oel":      "o.e: -l",
-	"ngati":    "nga: -ti",
-	"kameie":   "k·a.m·e: <ei>: see, see into, understand, know (spiritual sense)\nk··ä: <am,ei>: go",
-	"rumaut":   "ru.ma.ut",
-	"oe":       "*o.e",
-	"si":       "s··i",
-	"fmetan":   "*fme.tan\nfme.*tan",
-	"tìng":     "t··ìng",
-	"nari":     "na.ri",
-	"mal":      "mal",
-	"kxitx":    "kxitx",
-	"sänume":   "sä.*nu.me",
-	"säpeyki":  "s··i: <äp,eyk>",
-	"pori":     "po: -ri",
-	"fpomtokx": "fpom.*tokx",
-	"sì":       "sì",
-	"fpomron":  "fpom.*ron",
-	"yo'":      "y··o'",
-	"sunu":     "su.nu",
-	"oer":      "*o.e: -r",
-	"aymauti":  "*ma.u.ti: ay-",
-	"ayspxam":  "spxam: ay-",
-	"nìayfo":   "ay.*fo: nì-",
+	"kaltxì":        "kal.*txì",
+	"ma":            "ma",
+	"fmetokyu":      "fme.tok: -yu",
+	"fmetokmungwrr": "fme.tok: -mungwrr",
+	"fmeretok":      "fm·e.t·ok: <er>",
+	"lu":            "lu",
+	"oel":           "o.e: -l",
+	"ngati":         "nga: -ti",
+	"kameie":        "k·a.m·e: <ei>: see, see into, understand, know (spiritual sense)\nk··ä: <am,ei>: go",
+	"rumaut":        "ru.ma.ut",
+	"oe":            "*o.e",
+	"si":            "s··i",
+	"fmetan":        "*fme.tan\nfme.*tan",
+	"tìng":          "t··ìng",
+	"nari":          "na.ri",
+	"mal":           "mal",
+	"kxitx":         "kxitx",
+	"sänume":        "sä.*nu.me",
+	"säpeyki":       "s··i: <äp,eyk>",
+	"pori":          "po: -ri",
+	"fpomtokx":      "fpom.*tokx",
+	"sì":            "sì",
+	"fpomron":       "fpom.*ron",
+	"yo'":           "y··o'",
+	"sunu":          "su.nu",
+	"oer":           "*o.e: -r",
+	"aymauti":       "*ma.u.ti: ay-",
+	"ayspxam":       "spxam: ay-",
+	"nìayfo":        "ay.*fo: nì-",
+	"kiyevame":      "ki.ye.*va: -me",
+	"ulte":          "*ul.te",
+	"eywa":          "*ey.wa",
+	"ngahu":         "nga: -hu",
+	"tìkenong":      "tì.ke.*nong",
+	"tìkangkem":     "tì.*kang.kem",
+	"tsmukìri":      "tsmuk: -ìri",
+	"aynga":         "*ay.nga",
+	"kame":          "*ka.me\nka.*me",
+}
+
+func TestFilterTarget_Structure(t *testing.T) {
+	structure, ok := (&FilterTarget{Syllable: "Tìng"}).Structure()
+	assert.True(t, ok)
+	assert.Equal(t, "ng", structure.Coda)
+	assert.Equal(t, "T", structure.Onset)
+
+	_, ok = (&FilterTarget{Syllable: "-g"}).Structure()
+	assert.False(t, ok)
 }
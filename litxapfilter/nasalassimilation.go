@@ -5,6 +5,8 @@ import (
 	"strings"
 	"unicode"
 	"unicode/utf8"
+
+	"github.com/gissleh/litxap/litxaputil"
 )
 
 // NasalAssimilation is a Filter that replaces a nasal at the end of the current syllable
@@ -15,9 +17,8 @@ func NasalAssimilation(curr, next *FilterTarget) (*string, *string) {
 		return nil, nil
 	}
 
-	// Keep nasal assimilation within sentences.
-	after := strings.Trim(curr.After, "  \t\r")
-	if after != "" && after != "," {
+	// Keep nasal assimilation within clauses.
+	if !litxaputil.ContinuesClause(curr.After) {
 		return nil, nil
 	}
 
@@ -26,30 +27,30 @@ func NasalAssimilation(curr, next *FilterTarget) (*string, *string) {
 		return nil, nil
 	}
 
+	structure, ok := curr.Structure()
+	if !ok || !slices.ContainsFunc(nasals, func(nasal string) bool { return strings.EqualFold(structure.Coda, nasal) }) {
+		return nil, nil
+	}
+	nasal := strings.ToLower(structure.Coda)
+	withoutNasal := strings.TrimSuffix(curr.Syllable, structure.Coda)
+
 	for _, nr := range nasalAssimilationTable {
 		if _, has := hasPrefixFold(next.Syllable, nr[1]); has {
-			for _, nasal := range nasals {
-				if nasalInSyllable, has := hasSuffixFold(curr.Syllable, nasal); has {
-					if slices.Contains(nasals, nr[1]) {
-						// tìng nari => tì nari (omit first nasal)
-						changeTo := strings.TrimSuffix(curr.Syllable, nasalInSyllable)
-						return &changeTo, nil
-					} else if nasal == nr[0] {
-						// lumpe => lumpe (no change)
-						return nil, nil
-					}
-
-					r, _ := utf8.DecodeRuneInString(nasalInSyllable)
+			if slices.Contains(nasals, nr[1]) {
+				// tìng nari => tì nari (omit first nasal)
+				return &withoutNasal, nil
+			} else if nasal == nr[0] {
+				// lumpe => lumpe (no change)
+				return nil, nil
+			}
 
-					var changeTo string
-					if unicode.IsUpper(r) {
-						changeTo = strings.TrimSuffix(curr.Syllable, nasalInSyllable) + strings.ToUpper(nr[0])
-					} else {
-						changeTo = strings.TrimSuffix(curr.Syllable, nasalInSyllable) + nr[0]
-					}
-					return &changeTo, nil
-				}
+			var changeTo string
+			if r, _ := utf8.DecodeRuneInString(structure.Coda); unicode.IsUpper(r) {
+				changeTo = withoutNasal + strings.ToUpper(nr[0])
+			} else {
+				changeTo = withoutNasal + nr[0]
 			}
+			return &changeTo, nil
 		}
 	}
 
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/gissleh/litxap"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNasalAssimilation(t *testing.T) {
@@ -32,6 +35,8 @@ func TestNasalAssimilation(t *testing.T) {
 		{"fti", "", "a", ""},
 		{"syen", "", "", ""},
 		{"tseng", ".", "pe", ""},
+		{"Oeng", "", "pe", "Oem"},
+		{"Oeng", "", "ke", ""},
 	}
 
 	for _, row := range table {
@@ -56,3 +61,17 @@ func TestNasalAssimilation(t *testing.T) {
 		})
 	}
 }
+
+func TestNasalAssimilation_PhoneticIPA(t *testing.T) {
+	// The phonetic IPA does the same as the filter, so there should be nothing left for it to change.
+	for _, input := range []string{"Oe tìng nari.", "Fmetan mal lu!", "Tìng, nari.", "Tìng. Nari.", "Tìng kxitx."} {
+		t.Run(input, func(t *testing.T) {
+			line, err := litxap.RunLine(input, dummyDictionary)
+			require.NoError(t, err)
+
+			opts := litxap.IPAOptions{Transcription: litxap.IPAPhonetic}
+			filtered := ApplyFilters(line, Filter(NasalAssimilation))
+			assert.Equal(t, filtered.IPAWithOptions(nil, opts).String(), line.IPAWithOptions(nil, opts).String())
+		})
+	}
+}
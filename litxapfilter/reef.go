@@ -12,6 +12,10 @@ import (
     1. The ù/u distinction is not currently possible to make happen with filters
     2. There are vocabulary differences, like shawm (RN) vs omum (FN)
     3. There are grammatical differences, like topical being allowed at the end of the sentence in RN
+
+  The first two are handled by litxapreef, which changes the entries before running these filters. The third is
+  left out on purpose: the topical at the start of the sentence is fine in RN too, so litxapreef keeps the word
+  order of the input.
 */
 
 func ReefUnstressedAeAsE(curr, _ *FilterTarget) (*string, *string) {
@@ -0,0 +1,264 @@
+package litxapfilter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+// Registry maps names to filters and presets, so that a pipeline can be chosen at runtime. The zero value is not
+// usable, use NewRegistry or DefaultRegistry.
+type Registry struct {
+	filters map[string]AnyFilter
+	presets map[string][]string
+	order   [][2]string
+}
+
+// NewRegistry makes an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		filters: make(map[string]AnyFilter),
+		presets: make(map[string][]string),
+	}
+}
+
+// DefaultRegistry makes a registry with the filters in this package, the presets "casual-speech", "fast-speech" and
+// "reef-dialect", and the order they must be run in. The fast speech filters use StrengthMedium. It's a new registry
+// every time, so it can be added to.
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Register("sae-remover", SaeRemover)
+	r.Register("nasal-assimilation", NasalAssimilation)
+	r.Register("diphthong-from-weak-vowel", DiphthongFromWeakVowel)
+	r.Register("reanalyze-diphthongs", ReanalyzeDiphthongs)
+	r.Register("demote-ejectives-before-consonants", DemoteEjectivesBeforeConsonants)
+	r.Register("remove-repeated-ejective", RemoveRepeatedEjective)
+	r.Register("elide-unstressed-e-word-endings", ElideUnstressedEWordEndings)
+	r.Register("elide-mi-si-ni-before-ay", ElideMiSiNiBeforeAy)
+	r.Register("elide-adv-prefix-and-e", ElideAdvPrefixAndE)
+	r.Register("spell-oe-as-we", SpellOeAsWe)
+	r.Register("reef-unstressed-ae-as-e", ReefUnstressedAeAsE)
+	r.Register("reef-ejective-to-voiced", ReefEjectiveToVoiced)
+	r.Register("reef-drop-glottal-stops-between-vowels", ReefDropGlottalStopsBetweenVowels)
+	r.Register("reef-apply-ch-sh", ReefApplyChSh)
+	r.RegisterWindow("reduce-unstressed-i", ReduceUnstressedI(StrengthMedium))
+	r.Register("drop-glottal-stops-between-identical-vowels", DropGlottalStopsBetweenIdenticalVowels(StrengthMedium))
+	r.Register("collapse-identical-vowels-across-words", CollapseIdenticalVowelsAcrossWords(StrengthMedium))
+
+	// The ejective filters don't know of the voiced ones.
+	r.RequireOrder("demote-ejectives-before-consonants", "reef-ejective-to-voiced")
+	r.RequireOrder("remove-repeated-ejective", "reef-ejective-to-voiced")
+	// SaeRemover only looks for sä.
+	r.RequireOrder("sae-remover", "reef-unstressed-ae-as-e")
+	r.RequireOrder("diphthong-from-weak-vowel", "reanalyze-diphthongs")
+	// These look for ì, which should not be reduced yet.
+	r.RequireOrder("diphthong-from-weak-vowel", "reduce-unstressed-i")
+	r.RequireOrder("elide-mi-si-ni-before-ay", "reduce-unstressed-i")
+	r.RequireOrder("elide-adv-prefix-and-e", "reduce-unstressed-i")
+
+	r.RegisterPreset("casual-speech",
+		"sae-remover",
+		"elide-mi-si-ni-before-ay",
+		"elide-adv-prefix-and-e",
+		"elide-unstressed-e-word-endings",
+		"diphthong-from-weak-vowel",
+		"reanalyze-diphthongs",
+		"nasal-assimilation",
+		"demote-ejectives-before-consonants",
+		"remove-repeated-ejective",
+	)
+	r.RegisterPreset("fast-speech",
+		"casual-speech",
+		"drop-glottal-stops-between-identical-vowels",
+		"collapse-identical-vowels-across-words",
+		"reduce-unstressed-i",
+	)
+	r.RegisterPreset("reef-dialect",
+		"remove-repeated-ejective",
+		"reef-unstressed-ae-as-e",
+		"reef-ejective-to-voiced",
+		"reef-drop-glottal-stops-between-vowels",
+		"reef-apply-ch-sh",
+	)
+
+	return r
+}
+
+// Register adds a filter. It panics if the name is taken by another filter or preset.
+func (r *Registry) Register(name string, filter Filter) {
+	r.checkName(name)
+	r.filters[name] = filter
+}
+
+// RegisterWindow adds a window filter, like Register.
+func (r *Registry) RegisterWindow(name string, filter WindowFilter) {
+	r.checkName(name)
+	r.filters[name] = filter
+}
+
+// RegisterPreset adds a list of filters and presets to run in order. It panics if the name is taken or
+// any of the names are unknown.
+func (r *Registry) RegisterPreset(name string, names ...string) {
+	r.checkName(name)
+	for _, filterName := range names {
+		if !r.has(filterName) {
+			panic(fmt.Sprintf("litxapfilter: preset %#v has unknown filter %#v", name, filterName))
+		}
+	}
+
+	r.presets[name] = slices.Clone(names)
+}
+
+// RequireOrder makes Build fail if both filters are in the pipeline, but after is before before.
+func (r *Registry) RequireOrder(before, after string) {
+	r.order = append(r.order, [2]string{before, after})
+}
+
+// Names lists the filters and presets in alphabetical order.
+func (r *Registry) Names() []string {
+	res := make([]string, 0, len(r.filters)+len(r.presets))
+	for name := range r.filters {
+		res = append(res, name)
+	}
+	for name := range r.presets {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+
+	return res
+}
+
+// Build makes a pipeline of the filters and presets in order. Presets are expanded, and any filter that is already
+// in the pipeline is skipped.
+func (r *Registry) Build(names ...string) (*Pipeline, error) {
+	pipeline := &Pipeline{}
+	for _, name := range names {
+		err := r.addToPipeline(pipeline, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, order := range r.order {
+		before := slices.Index(pipeline.Names, order[0])
+		after := slices.Index(pipeline.Names, order[1])
+		if before != -1 && after != -1 && after < before {
+			return nil, fmt.Errorf("%w: %#v must come before %#v", ErrFilterOrder, order[0], order[1])
+		}
+	}
+
+	return pipeline, nil
+}
+
+// ParseConfig builds a pipeline from a list of filters and presets, separated by commas, spaces or lines. A # starts
+// a comment that lasts until the end of the line. E.g.:
+//
+//	# Casual Reef speech
+//	casual-speech
+//	reef-unstressed-ae-as-e, reef-ejective-to-voiced
+func (r *Registry) ParseConfig(config string) (*Pipeline, error) {
+	var names []string
+	for i, line := range strings.Split(config, "\n") {
+		if before, _, found := strings.Cut(line, "#"); found {
+			line = before
+		}
+
+		for _, name := range strings.FieldsFunc(line, isConfigSeparator) {
+			if !r.has(name) {
+				return nil, fmt.Errorf("line %d: %w: %#v", i+1, ErrUnknownFilter, name)
+			}
+
+			names = append(names, name)
+		}
+	}
+
+	return r.Build(names...)
+}
+
+func (r *Registry) addToPipeline(pipeline *Pipeline, name string) error {
+	if filter, ok := r.filters[name]; ok {
+		if !slices.Contains(pipeline.Names, name) {
+			pipeline.Names = append(pipeline.Names, name)
+			pipeline.Filters = append(pipeline.Filters, filter)
+		}
+
+		return nil
+	}
+
+	preset, ok := r.presets[name]
+	if !ok {
+		return fmt.Errorf("%w: %#v", ErrUnknownFilter, name)
+	}
+
+	for _, presetName := range preset {
+		err := r.addToPipeline(pipeline, presetName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) checkName(name string) {
+	if name == "" || strings.IndexFunc(name, isConfigSeparator) != -1 || strings.Contains(name, "#") {
+		panic(fmt.Sprintf("litxapfilter: invalid filter name %#v", name))
+	}
+	if r.has(name) {
+		panic(fmt.Sprintf("litxapfilter: filter %#v registered twice", name))
+	}
+}
+
+func (r *Registry) has(name string) bool {
+	_, isFilter := r.filters[name]
+	_, isPreset := r.presets[name]
+	return isFilter || isPreset
+}
+
+func isConfigSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t' || r == '\r'
+}
+
+// Pipeline is a list of filters built by a Registry.
+type Pipeline struct {
+	Names   []string
+	Filters []AnyFilter
+}
+
+// Apply runs the filters on the line with ApplyFilters.
+func (p *Pipeline) Apply(line litxap.Line) litxap.Line {
+	return ApplyFilters(line, p.Filters...)
+}
+
+// ApplyWithReport runs the filters like Apply, and reports the changes under the filters' names.
+func (p *Pipeline) ApplyWithReport(line litxap.Line) (litxap.Line, *FilterReport) {
+	report := &FilterReport{}
+	for i, filter := range p.Filters {
+		line = filter.applyWithReport(line, p.Names[i], report)
+	}
+
+	return line, report
+}
+
+// ApplySelected runs the filters with ApplyFiltersSelected, and reports the changes under the filters' names.
+func (p *Pipeline) ApplySelected(line litxap.Line, selections map[int]int) FilterResult {
+	return applySelected(line, selections, p.Names, p.Filters)
+}
+
+// ApplyToCombinations runs the filters with ApplyFiltersToCombinations, and reports the changes under the filters'
+// names.
+func (p *Pipeline) ApplyToCombinations(line litxap.Line, limit int) []FilterResult {
+	return applyToCombinations(line, limit, p.Names, p.Filters)
+}
+
+// String lists the filter names in the same format as ParseConfig.
+func (p *Pipeline) String() string {
+	return strings.Join(p.Names, ", ")
+}
+
+var ErrUnknownFilter = errors.New("unknown filter")
+var ErrFilterOrder = errors.New("filters are in the wrong order")
@@ -0,0 +1,129 @@
+package litxapfilter
+
+import (
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Build(t *testing.T) {
+	table := []struct {
+		name     string
+		input    []string
+		expected []string
+		err      error
+	}{
+		{"Filter", []string{"nasal-assimilation"}, []string{"nasal-assimilation"}, nil},
+		{"Preset", []string{"reef-dialect"}, []string{
+			"remove-repeated-ejective",
+			"reef-unstressed-ae-as-e",
+			"reef-ejective-to-voiced",
+			"reef-drop-glottal-stops-between-vowels",
+			"reef-apply-ch-sh",
+		}, nil},
+		{"Duplicates", []string{"sae-remover", "casual-speech", "reef-dialect"}, []string{
+			"sae-remover",
+			"elide-mi-si-ni-before-ay",
+			"elide-adv-prefix-and-e",
+			"elide-unstressed-e-word-endings",
+			"diphthong-from-weak-vowel",
+			"reanalyze-diphthongs",
+			"nasal-assimilation",
+			"demote-ejectives-before-consonants",
+			"remove-repeated-ejective",
+			"reef-unstressed-ae-as-e",
+			"reef-ejective-to-voiced",
+			"reef-drop-glottal-stops-between-vowels",
+			"reef-apply-ch-sh",
+		}, nil},
+		{"PresetInPreset", []string{"fast-speech"}, []string{
+			"sae-remover",
+			"elide-mi-si-ni-before-ay",
+			"elide-adv-prefix-and-e",
+			"elide-unstressed-e-word-endings",
+			"diphthong-from-weak-vowel",
+			"reanalyze-diphthongs",
+			"nasal-assimilation",
+			"demote-ejectives-before-consonants",
+			"remove-repeated-ejective",
+			"drop-glottal-stops-between-identical-vowels",
+			"collapse-identical-vowels-across-words",
+			"reduce-unstressed-i",
+		}, nil},
+		{"Unknown", []string{"nasal-assimilation", "casual"}, nil, ErrUnknownFilter},
+		{"Order", []string{"reef-ejective-to-voiced", "remove-repeated-ejective"}, nil, ErrFilterOrder},
+		{"OrderPreset", []string{"reef-dialect", "casual-speech"}, nil, ErrFilterOrder},
+		{"OrderUnrelated", []string{"reanalyze-diphthongs", "nasal-assimilation"}, []string{"reanalyze-diphthongs", "nasal-assimilation"}, nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			res, err := DefaultRegistry().Build(row.input...)
+			if row.err != nil {
+				assert.ErrorIs(t, err, row.err)
+				assert.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, row.expected, res.Names)
+			assert.Len(t, res.Filters, len(row.expected))
+		})
+	}
+}
+
+func TestRegistry_ParseConfig(t *testing.T) {
+	res, err := DefaultRegistry().ParseConfig("# Sä and ejectives\nsae-remover,demote-ejectives-before-consonants\n\n  reef-ejective-to-voiced # Reef\r\n")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sae-remover", "demote-ejectives-before-consonants", "reef-ejective-to-voiced"}, res.Names)
+	assert.Equal(t, "sae-remover, demote-ejectives-before-consonants, reef-ejective-to-voiced", res.String())
+
+	res2, err := DefaultRegistry().ParseConfig(res.String())
+	require.NoError(t, err)
+	assert.Equal(t, res.Names, res2.Names)
+
+	_, err = DefaultRegistry().ParseConfig("sae-remover\nnasal-asimilation")
+	assert.ErrorIs(t, err, ErrUnknownFilter)
+	assert.ErrorContains(t, err, "line 2")
+
+	res, err = DefaultRegistry().ParseConfig("# Nothing")
+	require.NoError(t, err)
+	assert.Empty(t, res.Names)
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := NewRegistry()
+	r.Register("demote", DemoteEjectivesBeforeConsonants)
+	r.Register("nasal", NasalAssimilation)
+	r.RegisterPreset("both", "demote", "nasal")
+	r.RequireOrder("nasal", "demote")
+
+	assert.Equal(t, []string{"both", "demote", "nasal"}, r.Names())
+	assert.Panics(t, func() { r.Register("both", SaeRemover) })
+	assert.Panics(t, func() { r.Register("two words", SaeRemover) })
+	assert.Panics(t, func() { r.RegisterPreset("none", "nothing") })
+
+	_, err := r.Build("both")
+	assert.ErrorIs(t, err, ErrFilterOrder)
+
+	res, err := r.Build("nasal", "demote")
+	require.NoError(t, err)
+
+	line, err := litxap.RunLine("Pori fpomtoKX sì fpomroN yo'.", dummyDictionary)
+	require.NoError(t, err)
+	filtered := res.Apply(line)
+	assert.NotEqual(t, line, filtered)
+	assert.Equal(t, ApplyFilters(line, Filter(NasalAssimilation), Filter(DemoteEjectivesBeforeConsonants)), filtered)
+
+	r.RegisterWindow("reduce", ReduceUnstressedI(StrengthStrong))
+	assert.Panics(t, func() { r.RegisterWindow("nasal", ReduceUnstressedI(StrengthStrong)) })
+
+	res, err = r.Build("nasal", "reduce")
+	require.NoError(t, err)
+
+	line, err = litxap.RunLine("Tìkangkem", dummyDictionary)
+	require.NoError(t, err)
+	assert.Equal(t, "Təkangkem", res.Apply(line)[0].Raw)
+}
@@ -0,0 +1,137 @@
+package litxapfilter
+
+import (
+	"slices"
+
+	"github.com/gissleh/litxap"
+)
+
+// FilterChange is a syllable that was changed by a filter.
+type FilterChange struct {
+	// Filter is the name of the filter, as it was given to ApplyFilterWithReport.
+	Filter string
+	// PartIndex, MatchIndex and SyllableIndex point to the syllable in the filtered line. If the syllable was removed,
+	// SyllableIndex is -1, and if the whole word was removed, all of them are.
+	PartIndex     int
+	MatchIndex    int
+	SyllableIndex int
+	// Before and After is the syllable before and after the change. After is empty if it was removed.
+	Before string
+	After  string
+}
+
+// FilterReport collects the changes made by filters, in the order they were made.
+type FilterReport struct {
+	Changes []FilterChange
+	// Parts has the index in the filtered line of every part in the line the report was first used with, or -1 for
+	// the parts that were removed.
+	Parts []int
+}
+
+// Filters lists the names of the filters that changed anything, in the order they first did.
+func (r *FilterReport) Filters() []string {
+	var res []string
+	for _, change := range r.Changes {
+		if !slices.Contains(res, change.Filter) {
+			res = append(res, change.Filter)
+		}
+	}
+
+	return res
+}
+
+// SyllableChanges lists the changes made to a syllable in the filtered line.
+func (r *FilterReport) SyllableChanges(pi, mi, si int) []FilterChange {
+	var res []FilterChange
+	for _, change := range r.Changes {
+		if change.PartIndex == pi && change.MatchIndex == mi && change.SyllableIndex == si {
+			res = append(res, change)
+		}
+	}
+
+	return res
+}
+
+// PartChanges lists the changes made to a word in the filtered line, including the removed syllables.
+func (r *FilterReport) PartChanges(pi int) []FilterChange {
+	var res []FilterChange
+	for _, change := range r.Changes {
+		if change.PartIndex == pi {
+			res = append(res, change)
+		}
+	}
+
+	return res
+}
+
+// start sets up Parts for the line, unless the report has been used already.
+func (r *FilterReport) start(line litxap.Line) {
+	if r.Parts != nil {
+		return
+	}
+
+	r.Parts = make([]int, len(line))
+	for pi := range r.Parts {
+		r.Parts[pi] = pi
+	}
+}
+
+// move updates the indices after ApplyFilterWithReport has removed syllables, matches and parts.
+func (r *FilterReport) move(syllableMoves map[[2]int][]int, matchMoves map[int][]int, deletedParts map[int]bool) {
+	for i, pi := range r.Parts {
+		if pi >= 0 {
+			r.Parts[i] = movePart(pi, deletedParts)
+		}
+	}
+
+	for i := range r.Changes {
+		change := &r.Changes[i]
+		if change.PartIndex < 0 {
+			continue
+		}
+
+		if moves, ok := syllableMoves[[2]int{change.PartIndex, change.MatchIndex}]; ok && change.SyllableIndex >= 0 {
+			change.SyllableIndex = moves[change.SyllableIndex]
+		}
+
+		if moves, ok := matchMoves[change.PartIndex]; ok {
+			change.MatchIndex = moves[change.MatchIndex]
+		}
+
+		if deletedParts[change.PartIndex] || change.MatchIndex < 0 {
+			change.PartIndex, change.MatchIndex, change.SyllableIndex = -1, -1, -1
+			continue
+		}
+
+		change.PartIndex = movePart(change.PartIndex, deletedParts)
+	}
+}
+
+// movePart gives the index of the part after the deleted parts are removed, or -1 if it is one of them.
+func movePart(pi int, deletedParts map[int]bool) int {
+	if deletedParts[pi] {
+		return -1
+	}
+
+	deletedBefore := 0
+	for deleted := range deletedParts {
+		if deleted < pi {
+			deletedBefore += 1
+		}
+	}
+
+	return pi - deletedBefore
+}
+
+// moveIndices moves the syllable indices to where they are after the cleared syllables are removed, leaving out
+// the ones that were removed.
+func moveIndices(indices []int, moves []int) []int {
+	var res []int
+	for _, index := range indices {
+		if index < len(moves) && moves[index] >= 0 {
+			res = append(res, moves[index])
+		}
+	}
+
+	return res
+}
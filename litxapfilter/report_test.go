@@ -0,0 +1,95 @@
+package litxapfilter
+
+import (
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline_ApplyWithReport(t *testing.T) {
+	line, err := litxap.RunLine("Kiyevame ulte aymauti, oe.", dummyDictionary)
+	require.NoError(t, err)
+
+	pipeline, err := DefaultRegistry().Build("casual-speech")
+	require.NoError(t, err)
+
+	res, report := pipeline.ApplyWithReport(line)
+	assert.Equal(t, pipeline.Apply(line)[0].Raw, res[0].Raw)
+	assert.Equal(t, []FilterChange{
+		{Filter: "elide-unstressed-e-word-endings", PartIndex: 2, MatchIndex: 0, SyllableIndex: 0, Before: "ul", After: "mul"},
+		{Filter: "elide-unstressed-e-word-endings", PartIndex: 0, MatchIndex: 0, SyllableIndex: -1, Before: "me", After: ""},
+		{Filter: "elide-unstressed-e-word-endings", PartIndex: 4, MatchIndex: 0, SyllableIndex: 0, Before: "ay", After: "tay"},
+		{Filter: "elide-unstressed-e-word-endings", PartIndex: 2, MatchIndex: 0, SyllableIndex: -1, Before: "te", After: ""},
+	}, report.Changes)
+	assert.Equal(t, []string{"elide-unstressed-e-word-endings"}, report.Filters())
+
+	assert.Equal(t, "Kiyeva", res[0].Raw)
+	assert.Nil(t, res[0].Matches[0].ChangedSyllables)
+	assert.Equal(t, "mul", res[2].Raw)
+	assert.Equal(t, []int{0}, res[2].Matches[0].ChangedSyllables)
+	assert.Equal(t, "taymauti", res[4].Raw)
+	assert.Equal(t, []int{0}, res[4].Matches[0].ChangedSyllables)
+	assert.Nil(t, res[6].Matches[0].ChangedSyllables)
+	assert.Nil(t, line[2].Matches[0].ChangedSyllables, "the input line should not be changed")
+}
+
+func TestApplyFilterWithReport(t *testing.T) {
+	line, err := litxap.RunLine("Kaltxì, ma kxitx sunu.", dummyDictionary)
+	require.NoError(t, err)
+
+	removeMaAndKx := func(curr, _ *FilterTarget) (*string, *string) {
+		switch curr.Syllable {
+		case "ma":
+			return new(string), nil
+		case "kxitx":
+			res := "kitx"
+			return &res, nil
+		}
+
+		return nil, nil
+	}
+	dropTx := func(curr, _ *FilterTarget) (*string, *string) {
+		if curr.Syllable == "kitx" {
+			res := "kit"
+			return &res, nil
+		}
+
+		return nil, nil
+	}
+	removeNu := func(curr, _ *FilterTarget) (*string, *string) {
+		if curr.Syllable == "nu" {
+			return new(string), nil
+		}
+
+		return nil, nil
+	}
+
+	report := &FilterReport{}
+	res := ApplyFilterWithReport(line, removeMaAndKx, "a", report)
+	res = ApplyFilterWithReport(res, dropTx, "b", report)
+	res = ApplyFilterWithReport(res, removeNu, "c", report)
+	res = ApplyFilterWithReport(res, removeNu, "d", report)
+
+	assert.Equal(t, []FilterChange{
+		{Filter: "a", PartIndex: -1, MatchIndex: -1, SyllableIndex: -1, Before: "ma", After: ""},
+		{Filter: "a", PartIndex: 2, MatchIndex: 0, SyllableIndex: 0, Before: "kxitx", After: "kitx"},
+		{Filter: "b", PartIndex: 2, MatchIndex: 0, SyllableIndex: 0, Before: "kitx", After: "kit"},
+		{Filter: "c", PartIndex: 4, MatchIndex: 0, SyllableIndex: -1, Before: "nu", After: ""},
+	}, report.Changes)
+	assert.Equal(t, []string{"a", "b", "c"}, report.Filters())
+	assert.Equal(t, report.Changes[1:3], report.SyllableChanges(2, 0, 0))
+	assert.Equal(t, report.Changes[3:], report.PartChanges(4))
+	assert.Nil(t, report.SyllableChanges(4, 0, 0))
+
+	raws := ""
+	for _, part := range res {
+		raws += part.Raw
+	}
+	assert.Equal(t, "Kaltxì, kit su.", raws)
+	assert.Equal(t, []int{0}, res[2].Matches[0].ChangedSyllables)
+	assert.Nil(t, res[4].Matches[0].ChangedSyllables)
+
+	assert.Equal(t, ApplyFilter(line, removeNu), ApplyFilterWithReport(line, removeNu, "e", nil))
+}
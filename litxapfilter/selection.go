@@ -0,0 +1,102 @@
+package litxapfilter
+
+import (
+	"github.com/gissleh/litxap"
+)
+
+// FilterResult is a line that was filtered with one match picked for every word. Since no word has more than one
+// match, a filter can't make changes for one match that don't fit with another, like eliding into the next word for
+// only one of the stress patterns.
+type FilterResult struct {
+	// Line is the filtered line. Every word has only the picked match, so its Raw is the text of that match.
+	Line litxap.Line
+	// Selections are the matches that were picked, by the index of the word in the input line. It has every word that
+	// had more than one match.
+	Selections map[int]int
+	// Report has the changes made by the filters, and where the parts of the input line ended up in Line.
+	Report *FilterReport
+}
+
+// ApplyFiltersSelected runs the filters on the line with only the selected match for every word. The words without a
+// valid selection get the first match, like litxap.Line.WithSelections with firstByDefault.
+func ApplyFiltersSelected(line litxap.Line, selections map[int]int, filters ...AnyFilter) FilterResult {
+	return applySelected(line, selections, nil, filters)
+}
+
+// ApplyFiltersToCombinations runs ApplyFiltersSelected for every combination of matches in the line, up to limit of
+// them. The first result has the first match of every word, and the last word with more than one match changes the
+// fastest.
+func ApplyFiltersToCombinations(line litxap.Line, limit int, filters ...AnyFilter) []FilterResult {
+	return applyToCombinations(line, limit, nil, filters)
+}
+
+func applySelected(line litxap.Line, selections map[int]int, names []string, filters []AnyFilter) FilterResult {
+	picked := make(map[int]int)
+	for pi, part := range line {
+		if len(part.Matches) < 2 {
+			continue
+		}
+
+		selection, ok := selections[pi]
+		if !ok || selection < 0 || selection >= len(part.Matches) {
+			selection = 0
+		}
+
+		picked[pi] = selection
+	}
+
+	report := &FilterReport{}
+	report.start(line)
+
+	filtered := line.WithSelections(picked, true)
+	for i, filter := range filters {
+		name := ""
+		if names != nil {
+			name = names[i]
+		}
+
+		filtered = filter.applyWithReport(filtered, name, report)
+	}
+
+	return FilterResult{Line: filtered, Selections: picked, Report: report}
+}
+
+func applyToCombinations(line litxap.Line, limit int, names []string, filters []AnyFilter) []FilterResult {
+	if limit <= 0 {
+		return nil
+	}
+
+	var ambiguous []int
+	for pi, part := range line {
+		if len(part.Matches) > 1 {
+			ambiguous = append(ambiguous, pi)
+		}
+	}
+
+	counters := make([]int, len(ambiguous))
+	var res []FilterResult
+	for len(res) < limit {
+		selections := make(map[int]int, len(ambiguous))
+		for i, pi := range ambiguous {
+			selections[pi] = counters[i]
+		}
+
+		res = append(res, applySelected(line, selections, names, filters))
+
+		// Count up like an odometer, with the last word as the lowest digit.
+		i := len(counters) - 1
+		for ; i >= 0; i-- {
+			counters[i] += 1
+			if counters[i] < len(line[ambiguous[i]].Matches) {
+				break
+			}
+
+			counters[i] = 0
+		}
+		if i < 0 {
+			break
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,142 @@
+package litxapfilter
+
+import (
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFiltersSelected(t *testing.T) {
+	table := []struct {
+		input      string
+		selections map[int]int
+		filters    []AnyFilter
+		expected   string
+		picked     map[int]int
+		parts      []int
+	}{
+		{
+			input:    "Kame ulte.",
+			filters:  []AnyFilter{Filter(ElideUnstressedEWordEndings)},
+			expected: "Ka multe.",
+			picked:   map[int]int{0: 0},
+			parts:    []int{0, 1, 2, 3},
+		},
+		{
+			input:      "Kame ulte.",
+			selections: map[int]int{0: 1},
+			filters:    []AnyFilter{Filter(ElideUnstressedEWordEndings)},
+			expected:   "Kame ulte.",
+			picked:     map[int]int{0: 1},
+			parts:      []int{0, 1, 2, 3},
+		},
+		{
+			input:      "Kame ulte.",
+			selections: map[int]int{0: 5},
+			filters:    []AnyFilter{Filter(ElideUnstressedEWordEndings)},
+			expected:   "Ka multe.",
+			picked:     map[int]int{0: 0},
+			parts:      []int{0, 1, 2, 3},
+		},
+		{
+			input:      "Ma aynga, fmetan!",
+			selections: map[int]int{4: 1},
+			filters:    []AnyFilter{CollapseIdenticalVowelsAcrossWords(StrengthLight)},
+			expected:   "Maynga, fmetan!",
+			picked:     map[int]int{4: 1},
+			parts:      []int{-1, -1, 0, 1, 2, 3},
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			line, err := litxap.RunLine(row.input, dummyDictionary)
+			require.NoError(t, err)
+
+			res := ApplyFiltersSelected(line, row.selections, row.filters...)
+			assert.Equal(t, row.expected, rawText(res.Line))
+			assert.Equal(t, row.picked, res.Selections)
+			assert.Equal(t, row.parts, res.Report.Parts)
+			for _, part := range res.Line {
+				if part.IsWord {
+					require.Len(t, part.Matches, 1)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyFiltersSelected_StressedMatch(t *testing.T) {
+	line, err := litxap.RunLine("Kame ulte.", dummyDictionary)
+	require.NoError(t, err)
+
+	// With both matches, the elision from the first one changes ulte for the second as well.
+	all := ApplyFilters(line, Filter(ElideUnstressedEWordEndings))
+	assert.Equal(t, []string{"Ka", "me"}, all[0].Matches[1].Syllables)
+	assert.Equal(t, "multe", all[2].Raw)
+
+	res := ApplyFiltersSelected(line, map[int]int{0: 1}, Filter(ElideUnstressedEWordEndings))
+	assert.Equal(t, "ulte", res.Line[2].Raw)
+	assert.Equal(t, 1, res.Line[0].Matches[0].Stress)
+	assert.Empty(t, res.Report.Changes)
+}
+
+func TestApplyFiltersToCombinations(t *testing.T) {
+	line, err := litxap.RunLine("Fmetan kame ulte.", dummyDictionary)
+	require.NoError(t, err)
+
+	results := ApplyFiltersToCombinations(line, 10, Filter(ElideUnstressedEWordEndings))
+	require.Len(t, results, 4)
+
+	var texts []string
+	var selections []map[int]int
+	for _, res := range results {
+		texts = append(texts, rawText(res.Line))
+		selections = append(selections, res.Selections)
+	}
+
+	assert.Equal(t, []string{
+		"Fmetan ka multe.",
+		"Fmetan kame ulte.",
+		"Fmetan ka multe.",
+		"Fmetan kame ulte.",
+	}, texts)
+	assert.Equal(t, []map[int]int{
+		{0: 0, 2: 0},
+		{0: 0, 2: 1},
+		{0: 1, 2: 0},
+		{0: 1, 2: 1},
+	}, selections)
+
+	assert.Len(t, ApplyFiltersToCombinations(line, 3, Filter(ElideUnstressedEWordEndings)), 3)
+	assert.Nil(t, ApplyFiltersToCombinations(line, 0, Filter(ElideUnstressedEWordEndings)))
+}
+
+func TestPipeline_ApplySelected(t *testing.T) {
+	line, err := litxap.RunLine("Kame ulte.", dummyDictionary)
+	require.NoError(t, err)
+
+	pipeline, err := DefaultRegistry().Build("elide-unstressed-e-word-endings")
+	require.NoError(t, err)
+
+	res := pipeline.ApplySelected(line, nil)
+	assert.Equal(t, "Ka multe.", rawText(res.Line))
+	assert.Equal(t, []string{"elide-unstressed-e-word-endings"}, res.Report.Filters())
+	assert.Equal(t, []int{0}, res.Line[2].Matches[0].ChangedSyllables)
+
+	results := pipeline.ApplyToCombinations(line, 10)
+	require.Len(t, results, 2)
+	assert.Equal(t, "Kame ulte.", rawText(results[1].Line))
+	assert.Empty(t, results[1].Report.Filters())
+}
+
+func rawText(line litxap.Line) string {
+	res := ""
+	for _, part := range line {
+		res += part.Raw
+	}
+
+	return res
+}
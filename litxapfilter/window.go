@@ -0,0 +1,170 @@
+package litxapfilter
+
+import (
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+// A WindowFilter is a filter that can see the whole line, for rules that need more than two syllables. It is called
+// once for every syllable of every match, and makes its changes with Cursor.Set. It can be run with ApplyFilters
+// alongside the other filters.
+type WindowFilter func(cursor *Cursor)
+
+// Cursor points to a syllable in the line that is being filtered. It sees the changes made so far, including those
+// made by the filter it's passed to.
+type Cursor struct {
+	PartIndex     int
+	MatchIndex    int
+	SyllableIndex int
+
+	line *litxap.Line
+	set  func(pi, mi, si int, value string)
+}
+
+// Line is the line with the changes made so far.
+func (c *Cursor) Line() litxap.Line {
+	return *c.line
+}
+
+// Match is the match the syllable is in.
+func (c *Cursor) Match() *litxap.LinePartMatch {
+	return &(*c.line)[c.PartIndex].Matches[c.MatchIndex]
+}
+
+// Entry is the entry of the match.
+func (c *Cursor) Entry() *litxap.Entry {
+	return &c.Match().Entry
+}
+
+// Syllable is the current text of the syllable.
+func (c *Cursor) Syllable() string {
+	return c.Match().Syllables[c.SyllableIndex]
+}
+
+// Stressed is true if the syllable has the stress of the word.
+func (c *Cursor) Stressed() bool {
+	return c.Match().Stress == c.SyllableIndex
+}
+
+// Set changes the syllable. It works the same as changing it from a Filter, so an empty string removes it.
+func (c *Cursor) Set(value string) {
+	c.set(c.PartIndex, c.MatchIndex, c.SyllableIndex, value)
+}
+
+// Prev is the syllable before this one. It goes into the word before if this is the first, where it picks the first
+// match. It is nil at the start of the line, or if the word before has no matches.
+func (c *Cursor) Prev() *Cursor {
+	line := *c.line
+	for si := c.SyllableIndex - 1; si >= 0; si-- {
+		if c.Match().Syllables[si] != "" {
+			return c.at(c.PartIndex, c.MatchIndex, si)
+		}
+	}
+
+	for pi := c.PartIndex - 1; pi >= 0; pi-- {
+		if !line[pi].IsWord {
+			continue
+		}
+		if len(line[pi].Matches) == 0 {
+			return nil
+		}
+
+		syllables := line[pi].Matches[0].Syllables
+		for si := len(syllables) - 1; si >= 0; si-- {
+			if syllables[si] != "" {
+				return c.at(pi, 0, si)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Next is the syllable after this one. Like Prev, it picks the first match of the next word, and it is nil at the
+// end of the line or if the word after has no matches.
+func (c *Cursor) Next() *Cursor {
+	line := *c.line
+	syllables := c.Match().Syllables
+	for si := c.SyllableIndex + 1; si < len(syllables); si++ {
+		if syllables[si] != "" {
+			return c.at(c.PartIndex, c.MatchIndex, si)
+		}
+	}
+
+	for pi := c.PartIndex + 1; pi < len(line); pi++ {
+		if !line[pi].IsWord {
+			continue
+		}
+		if len(line[pi].Matches) == 0 {
+			return nil
+		}
+
+		for si, syllable := range line[pi].Matches[0].Syllables {
+			if syllable != "" {
+				return c.at(pi, 0, si)
+			}
+		}
+	}
+
+	return nil
+}
+
+// WordStart is true for the first syllable of the word.
+func (c *Cursor) WordStart() bool {
+	for _, syllable := range c.Match().Syllables[:c.SyllableIndex] {
+		if syllable != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WordEnd is true for the last syllable of the word.
+func (c *Cursor) WordEnd() bool {
+	for _, syllable := range c.Match().Syllables[c.SyllableIndex+1:] {
+		if syllable != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Before is the text between this word and the one before it, like After.
+func (c *Cursor) Before() string {
+	line := *c.line
+	raw := ""
+	for pi := c.PartIndex - 1; pi >= 0 && !line[pi].IsWord; pi-- {
+		raw = line[pi].Raw + raw
+	}
+
+	return raw
+}
+
+// After is the text between this word and the next, e.g. ", " or " ". It's the whole rest of the line
+// for the last word.
+func (c *Cursor) After() string {
+	return nonWordAfter(*c.line, c.PartIndex)
+}
+
+// SentenceStart is true if the word is the first in the line or comes after a sentence ends.
+func (c *Cursor) SentenceStart() bool {
+	return c.PartIndex == firstWord(*c.line) || strings.ContainsAny(c.Before(), sentenceEnders)
+}
+
+// SentenceEnd is true if the word is the last in the line or comes before a sentence ends.
+func (c *Cursor) SentenceEnd() bool {
+	return nextPartAfter(*c.line, c.PartIndex) == -1 || strings.ContainsAny(c.After(), sentenceEnders)
+}
+
+func (c *Cursor) at(pi, mi, si int) *Cursor {
+	return &Cursor{PartIndex: pi, MatchIndex: mi, SyllableIndex: si, line: c.line, set: c.set}
+}
+
+func firstWord(line litxap.Line) int {
+	return nextPartAfter(line, -1)
+}
+
+const sentenceEnders = ".!?"
@@ -0,0 +1,122 @@
+package litxapfilter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowFilter_Cursor(t *testing.T) {
+	line, err := litxap.RunLine("Kaltxì, ma sunu. Oe lu kxitx!", dummyDictionary)
+	require.NoError(t, err)
+
+	var visits []string
+	res := ApplyFilters(line, WindowFilter(func(c *Cursor) {
+		prev, next := "-", "-"
+		if p := c.Prev(); p != nil {
+			prev = p.Syllable()
+		}
+		if n := c.Next(); n != nil {
+			next = n.Syllable()
+		}
+
+		flags := ""
+		for _, flag := range []struct {
+			name string
+			set  bool
+		}{
+			{"S", c.Stressed()}, {"<", c.WordStart()}, {">", c.WordEnd()},
+			{"[", c.SentenceStart()}, {"]", c.SentenceEnd()},
+		} {
+			if flag.set {
+				flags += flag.name
+			}
+		}
+
+		visits = append(visits, fmt.Sprintf("%s %s %s %s %q %q", prev, c.Syllable(), next, flags, c.Before(), c.After()))
+	}))
+
+	assert.Equal(t, line, res)
+	assert.Equal(t, []string{
+		`- Kal txì <[ "" ", "`,
+		`Kal txì ma S>[ "" ", "`,
+		`txì ma su S<> ", " " "`,
+		`ma su nu S<] " " ". "`,
+		`su nu O >] " " ". "`,
+		`nu O e S<[ ". " " "`,
+		`O e lu >[ ". " " "`,
+		`e lu kxitx S<> " " " "`,
+		`lu kxitx - S<>] " " "!"`,
+	}, visits)
+}
+
+func TestWindowFilter_Set(t *testing.T) {
+	// This elides an unstressed syllable between two stressed ones, which can't be done with a two-syllable window.
+	elideBetweenStresses := WindowFilter(func(c *Cursor) {
+		prev, next := c.Prev(), c.Next()
+		if c.Stressed() || prev == nil || next == nil || !prev.Stressed() || !next.Stressed() {
+			return
+		}
+		if c.WordStart() && c.WordEnd() {
+			return
+		}
+
+		c.Set("")
+	})
+
+	line, err := litxap.RunLine("Ma sunu lu.", dummyDictionary)
+	require.NoError(t, err)
+
+	res := ApplyFilters(line, elideBetweenStresses, Filter(NasalAssimilation))
+	assert.Equal(t, litxap.Line{
+		{Raw: "Ma", IsWord: true, Matches: []litxap.LinePartMatch{
+			{Syllables: []string{"Ma"}, Stress: 0, Entry: dummyDictionary.entry("ma", 0)},
+		}},
+		{Raw: " "},
+		{Raw: "su", Original: "sunu", IsWord: true, Matches: []litxap.LinePartMatch{
+			{Syllables: []string{"su"}, Stress: 0, Entry: dummyDictionary.entry("sunu", 0)},
+		}},
+		{Raw: " "},
+		{Raw: "lu", IsWord: true, Matches: []litxap.LinePartMatch{
+			{Syllables: []string{"lu"}, Stress: 0, Entry: dummyDictionary.entry("lu", 0)},
+		}},
+		{Raw: "."},
+	}, res)
+	assert.Equal(t, "sunu", line[2].Raw)
+	assert.Equal(t, []string{"su", "nu"}, line[2].Matches[0].Syllables)
+
+	unchanged, err := litxap.RunLine("Kaltxì, ma sunu.", dummyDictionary)
+	require.NoError(t, err)
+	assert.Same(t, &unchanged[0], &ApplyFilters(unchanged, elideBetweenStresses)[0])
+}
+
+func TestWindowFilter_Ambiguous(t *testing.T) {
+	line, err := litxap.RunLine("Fmetan oe.", dummyDictionary)
+	require.NoError(t, err)
+	require.Len(t, line[0].Matches, 2)
+
+	var visits []string
+	ApplyFilters(line, WindowFilter(func(c *Cursor) {
+		visits = append(visits, fmt.Sprintf("%d:%d:%s", c.MatchIndex, c.SyllableIndex, c.Syllable()))
+	}))
+
+	assert.Equal(t, []string{"0:0:Fme", "0:1:tan", "1:0:Fme", "1:1:tan", "0:0:o", "0:1:e"}, visits)
+}
+
+func TestApplyWindowFilterWithReport(t *testing.T) {
+	line, err := litxap.RunLine("Ma tsmukìri!", dummyDictionary)
+	require.NoError(t, err)
+
+	report := &FilterReport{}
+	res := ApplyWindowFilterWithReport(line, ReduceUnstressedI(StrengthMedium), "reduce", report)
+	assert.Equal(t, "tsmukəri", res[2].Raw)
+	assert.Equal(t, []int{1}, res[2].Matches[0].ChangedSyllables)
+	assert.Equal(t, []FilterChange{
+		{Filter: "reduce", PartIndex: 2, MatchIndex: 0, SyllableIndex: 1, Before: "kì", After: "kə"},
+	}, report.Changes)
+
+	assert.Equal(t, res, ApplyFiltersSelected(line, nil, ReduceUnstressedI(StrengthMedium)).Line)
+}
@@ -0,0 +1,168 @@
+package litxapformats
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+// ANSIDefaultColors returns the same as ANSI with an underlined stressed syllable, yellow ambiguities,
+// red no-matches and cyan any-stress words. If the NO_COLOR environment variable is set to anything but
+// an empty string, the colors are left out and only the underline remains.
+func ANSIDefaultColors() litxap.LineFormatter {
+	opts := ANSIOptions{
+		StressUnderline:  true,
+		AmbiguousColor:   ANSIColor16(3),
+		NoMatchesColor:   ANSIColor16(1),
+		AnyStressColor:   ANSIColor16(6),
+		DisableAllColors: os.Getenv("NO_COLOR") != "",
+	}
+
+	return ANSI(opts)
+}
+
+// ANSI formats the line with ANSI escape sequences for terminals.
+func ANSI(opts ANSIOptions) litxap.LineFormatter {
+	f := &ansiFormatter{}
+
+	if !opts.DisableAllColors {
+		f.amColor = opts.AmbiguousColor.sequence()
+		f.nmColor = opts.NoMatchesColor.sequence()
+		f.asColor = opts.AnyStressColor.sequence()
+	}
+
+	var open, close []string
+	if opts.StressBold {
+		open = append(open, "1")
+		close = append(close, "22")
+	}
+	if opts.StressUnderline {
+		open = append(open, "4")
+		close = append(close, "24")
+	}
+	if len(open) > 0 {
+		f.stressOpen = "\x1b[" + strings.Join(open, ";") + "m"
+		f.stressClose = "\x1b[" + strings.Join(close, ";") + "m"
+	}
+	if opts.SecondaryStressDim {
+		f.secondaryOpen = "\x1b[2m"
+		f.secondaryClose = "\x1b[22m"
+	}
+	if opts.ChangedItalic {
+		f.changedOpen = "\x1b[3m"
+		f.changedClose = "\x1b[23m"
+	}
+
+	return f
+}
+
+type ANSIOptions struct {
+	// StressBold makes the stressed syllable bold.
+	StressBold bool
+	// StressUnderline underlines the stressed syllable.
+	StressUnderline bool
+	// SecondaryStressDim makes the syllables with secondary stress dim, or faint in some terminals.
+	SecondaryStressDim bool
+	// ChangedItalic makes the syllables changed by filters italic, see litxapfilter.ApplyFilterWithReport.
+	ChangedItalic bool
+	// AmbiguousColor is used for words with multiple matches that disagree on stress.
+	AmbiguousColor ANSIColor
+	// NoMatchesColor is used for words without any matches.
+	NoMatchesColor ANSIColor
+	// AnyStressColor is used for words like ìlä where any stress is fine.
+	AnyStressColor ANSIColor
+	// DisableAllColors leaves out all colors, but keeps the stress markings.
+	DisableAllColors bool
+}
+
+// ANSIColor is a foreground color. The zero value means no color.
+type ANSIColor struct {
+	mode  int
+	value [3]uint8
+}
+
+// ANSIColor16 gives one of the 16 basic terminal colors, where 0-7 are the normal colors and 8-15 their bright
+// variants. The actual color depends on the terminal's palette.
+func ANSIColor16(index int) ANSIColor {
+	return ANSIColor{mode: ansiMode16, value: [3]uint8{uint8(index & 0xf)}}
+}
+
+// ANSIColor256 gives a color from the 256 color palette.
+func ANSIColor256(index uint8) ANSIColor {
+	return ANSIColor{mode: ansiMode256, value: [3]uint8{index}}
+}
+
+// ANSIColorRGB gives a 24-bit color, which is not supported by all terminals.
+func ANSIColorRGB(r, g, b uint8) ANSIColor {
+	return ANSIColor{mode: ansiModeRGB, value: [3]uint8{r, g, b}}
+}
+
+func (c ANSIColor) sequence() string {
+	switch c.mode {
+	case ansiMode16:
+		if c.value[0] >= 8 {
+			return fmt.Sprintf("\x1b[%dm", 90+c.value[0]-8)
+		}
+
+		return fmt.Sprintf("\x1b[%dm", 30+c.value[0])
+	case ansiMode256:
+		return fmt.Sprintf("\x1b[38;5;%dm", c.value[0])
+	case ansiModeRGB:
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c.value[0], c.value[1], c.value[2])
+	default:
+		return ""
+	}
+}
+
+const (
+	ansiModeNone = iota
+	ansiMode16
+	ansiMode256
+	ansiModeRGB
+)
+
+const ansiResetColor = "\x1b[39m"
+
+type ansiFormatter struct {
+	amColor        string
+	nmColor        string
+	asColor        string
+	stressOpen     string
+	stressClose    string
+	secondaryOpen  string
+	secondaryClose string
+	changedOpen    string
+	changedClose   string
+}
+
+func (f *ansiFormatter) LinePartTags(_ litxap.LinePart, stress int) (string, string) {
+	var color string
+	switch stress {
+	case litxap.LPSAmbiguousMatches:
+		color = f.amColor
+	case litxap.LPSNoMatches:
+		color = f.nmColor
+	case litxap.LPSAnyStress:
+		color = f.asColor
+	}
+
+	if color == "" {
+		return "", ""
+	}
+
+	return color, ansiResetColor
+}
+
+func (f *ansiFormatter) StressedSyllableTags() (string, string) {
+	return f.stressOpen, f.stressClose
+}
+
+func (f *ansiFormatter) SecondaryStressedSyllableTags() (string, string) {
+	return f.secondaryOpen, f.secondaryClose
+}
+
+func (f *ansiFormatter) ChangedSyllableTags() (string, string) {
+	return f.changedOpen, f.changedClose
+}
@@ -0,0 +1,109 @@
+package litxapformats
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestANSI(t *testing.T) {
+	table := []struct {
+		input      litxap.Line
+		opts       ANSIOptions
+		output     string
+		selections map[int]int
+	}{
+		{
+			lineOelNgatiKameie,
+			ANSIOptions{StressUnderline: true, AmbiguousColor: ANSIColor16(3)},
+			"Oel \x1b[4mnga\x1b[24mti \x1b[33mkameie\x1b[39m.",
+			nil,
+		},
+		{
+			lineOelNgatiKameie,
+			ANSIOptions{StressBold: true, StressUnderline: true},
+			"Oel \x1b[1;4mnga\x1b[22;24mti \x1b[1;4mka\x1b[22;24mmeie.",
+			map[int]int{4: 0},
+		},
+		{
+			lineKaltxiMaFmetokyu,
+			ANSIOptions{StressBold: true},
+			"Kal\x1b[1mtxì\x1b[22m, ma \x1b[1mfme\x1b[22mtokyu!",
+			nil,
+		},
+		{
+			lineVolaSkeynven,
+			ANSIOptions{StressUnderline: true, NoMatchesColor: ANSIColor16(9)},
+			"\x1b[4mVo\x1b[24mla \x1b[91mskeynven\x1b[39m.",
+			nil,
+		},
+		{
+			lineVolaSkeynven,
+			ANSIOptions{StressUnderline: true, NoMatchesColor: ANSIColor256(196)},
+			"\x1b[4mVo\x1b[24mla \x1b[38;5;196mskeynven\x1b[39m.",
+			nil,
+		},
+		{
+			lineFikemIlaFyao,
+			ANSIOptions{StressUnderline: true, AnyStressColor: ANSIColorRGB(135, 206, 235)},
+			"Fì\x1b[4mkem\x1b[24m \x1b[38;2;135;206;235mìlä\x1b[39m \x1b[4mfya\x1b[24m'o!",
+			map[int]int{2: 2},
+		},
+		{
+			lineMrrvomrr,
+			ANSIOptions{StressUnderline: true, SecondaryStressDim: true},
+			"\x1b[2mMrr\x1b[22mvo\x1b[4mmrr\x1b[24m.",
+			nil,
+		},
+		{
+			lineMrrvomrr,
+			ANSIOptions{StressUnderline: true},
+			"Mrrvo\x1b[4mmrr\x1b[24m.",
+			nil,
+		},
+		{
+			lineKiyevaMulte,
+			ANSIOptions{StressUnderline: true, ChangedItalic: true},
+			"Kiye\x1b[4mva\x1b[24m \x1b[3m\x1b[4mmul\x1b[24m\x1b[23mte.",
+			nil,
+		},
+		{
+			lineKiyevaMulte,
+			ANSIOptions{StressUnderline: true},
+			"Kiye\x1b[4mva\x1b[24m \x1b[4mmul\x1b[24mte.",
+			nil,
+		},
+		{
+			lineVolaSkeynven,
+			ANSIOptions{StressUnderline: true, NoMatchesColor: ANSIColor16(1), DisableAllColors: true},
+			"\x1b[4mVo\x1b[24mla skeynven.",
+			nil,
+		},
+	}
+
+	for i, row := range table {
+		t.Run(fmt.Sprintf("row_%d", i), func(t *testing.T) {
+			assert.Equal(t, row.output, row.input.Format(ANSI(row.opts), row.selections))
+		})
+	}
+}
+
+func TestANSIDefaultColors(t *testing.T) {
+	t.Run("Colors", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "")
+		assert.Equal(t,
+			"Oel \x1b[4mnga\x1b[24mti \x1b[33mkameie\x1b[39m.",
+			lineOelNgatiKameie.Format(ANSIDefaultColors(), nil),
+		)
+	})
+
+	t.Run("NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		assert.Equal(t,
+			"Oel \x1b[4mnga\x1b[24mti kameie.",
+			lineOelNgatiKameie.Format(ANSIDefaultColors(), nil),
+		)
+	})
+}
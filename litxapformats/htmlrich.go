@@ -0,0 +1,177 @@
+package litxapformats
+
+import (
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+// HTMLRich formats using <span></span> around words with data attributes from the entry: data-id, data-translation and
+// data-affixes. It uses the same class names as CompactHTML, <u> for the stressed syllable and <span class="ss"> for
+// syllables with secondary stress. Words with a guessed entry, see litxap.GuessDictionary, get the class "gs", and
+// syllables changed by filters are wrapped in <span class="fc">.
+func HTMLRich(opts HTMLRichOptions) litxap.LineFormatter {
+	return &htmlRichFormatter{opts: opts}
+}
+
+type HTMLRichOptions struct {
+	// RubyIPA adds the IPA of each word as a <ruby> annotation.
+	RubyIPA bool
+	// IPA is the options for the RubyIPA annotation. With Fallback set, the words without matches get one too.
+	IPA litxap.IPAOptions
+	// Alternatives adds a <select> after ambiguous words with an <option> for each match. The value
+	// is the match index, so it can be used in the selections.
+	Alternatives bool
+}
+
+type htmlRichFormatter struct {
+	opts HTMLRichOptions
+}
+
+func (f *htmlRichFormatter) LinePartTags(_ litxap.LinePart, stress int) (string, string) {
+	if stress == litxap.LPSNotWord {
+		return "", ""
+	}
+
+	return f.classSpan(stress), "</span>"
+}
+
+func (f *htmlRichFormatter) StressedSyllableTags() (string, string) {
+	return "<u>", "</u>"
+}
+
+func (f *htmlRichFormatter) EscapeText(s string) string {
+	return html.EscapeString(s)
+}
+
+func (f *htmlRichFormatter) WordTags(part litxap.LinePart, match *litxap.LinePartMatch, stress int) (string, string) {
+	if match == nil {
+		open := f.classSpan(stress)
+		if stress == litxap.LPSAmbiguousMatches && f.opts.Alternatives {
+			return open, f.alternatives(part) + "</span>"
+		}
+		if stress == litxap.LPSNoMatches {
+			return f.ruby(open, part, -1)
+		}
+
+		return open, "</span>"
+	}
+
+	classSpan := f.classSpan(stress)
+	if match.Entry.Guessed && classSpan == "<span>" {
+		classSpan = "<span class=\"gs\">"
+	}
+
+	open := &strings.Builder{}
+	open.WriteString(strings.TrimSuffix(classSpan, ">"))
+	writeHTMLAttribute(open, "data-id", match.Entry.ID)
+	writeHTMLAttribute(open, "data-translation", match.Entry.Translation)
+	writeHTMLAttribute(open, "data-affixes", entryAffixes(&match.Entry))
+	open.WriteString(">")
+
+	selection := -1
+	for i := range part.Matches {
+		if &part.Matches[i] == match {
+			selection = i
+		}
+	}
+
+	return f.ruby(open.String(), part, selection)
+}
+
+// ruby adds the <ruby> annotation to the word if RubyIPA is set and it can be transcribed.
+func (f *htmlRichFormatter) ruby(open string, part litxap.LinePart, selection int) (string, string) {
+	if f.opts.RubyIPA {
+		if ipa := part.IPA(selection, f.opts.IPA); ipa.Err == nil {
+			return open + "<ruby>", "<rt>" + html.EscapeString(ipa.IPA) + "</rt></ruby></span>"
+		}
+	}
+
+	return open, "</span>"
+}
+
+func (f *htmlRichFormatter) SyllableTags(match *litxap.LinePartMatch, index int, stressed bool) (string, string) {
+	if stressed && len(match.Syllables) > 1 {
+		return "<u>", "</u>"
+	}
+	if slices.Contains(match.SecondaryStress, index) {
+		return "<span class=\"ss\">", "</span>"
+	}
+
+	return "", ""
+}
+
+func (f *htmlRichFormatter) ChangedSyllableTags() (string, string) {
+	return "<span class=\"fc\">", "</span>"
+}
+
+func (f *htmlRichFormatter) classSpan(stress int) string {
+	switch stress {
+	case litxap.LPSAmbiguousMatches:
+		return "<span class=\"am\">"
+	case litxap.LPSNoMatches:
+		return "<span class=\"nm\">"
+	case litxap.LPSAnyStress:
+		return "<span class=\"as\">"
+	default:
+		return "<span>"
+	}
+}
+
+func (f *htmlRichFormatter) alternatives(part litxap.LinePart) string {
+	sb := &strings.Builder{}
+	sb.WriteString("<select>")
+	for i, match := range part.Matches {
+		sb.WriteString(fmt.Sprintf("<option value=\"%d\">", i))
+		for j, syllable := range match.Syllables {
+			if j > 0 {
+				sb.WriteString("·")
+			}
+			if j == match.Stress && len(match.Syllables) > 1 {
+				syllable = strings.ToUpper(syllable)
+			}
+
+			sb.WriteString(html.EscapeString(syllable))
+		}
+		if match.Entry.Translation != "" {
+			sb.WriteString(" (")
+			sb.WriteString(html.EscapeString(match.Entry.Translation))
+			sb.WriteString(")")
+		}
+		sb.WriteString("</option>")
+	}
+	sb.WriteString("</select>")
+
+	return sb.String()
+}
+
+func writeHTMLAttribute(sb *strings.Builder, name, value string) {
+	if value == "" {
+		return
+	}
+
+	sb.WriteByte(' ')
+	sb.WriteString(name)
+	sb.WriteString("=\"")
+	sb.WriteString(html.EscapeString(value))
+	sb.WriteByte('"')
+}
+
+// entryAffixes lists the affixes in the same style as litxap.Entry.String, e.g. "tì- <us> -ìri".
+func entryAffixes(entry *litxap.Entry) string {
+	affixes := make([]string, 0, len(entry.Prefixes)+len(entry.Infixes)+len(entry.Suffixes))
+	for _, prefix := range entry.Prefixes {
+		affixes = append(affixes, prefix+"-")
+	}
+	for _, infix := range entry.Infixes {
+		affixes = append(affixes, "<"+infix+">")
+	}
+	for _, suffix := range entry.Suffixes {
+		affixes = append(affixes, "-"+suffix)
+	}
+
+	return strings.Join(affixes, " ")
+}
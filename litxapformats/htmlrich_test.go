@@ -0,0 +1,95 @@
+package litxapformats
+
+import (
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLRich(t *testing.T) {
+	table := []struct {
+		input      litxap.Line
+		opts       HTMLRichOptions
+		output     string
+		selections map[int]int
+	}{
+		{
+			lineOelNgatiKameie, HTMLRichOptions{},
+			`<span data-affixes="-l">Oel</span> <span data-affixes="-ti"><u>nga</u>ti</span> <span class="am">kameie</span>.`,
+			nil,
+		},
+		{
+			lineOelNgatiKameie, HTMLRichOptions{Alternatives: true},
+			`<span data-affixes="-l">Oel</span> <span data-affixes="-ti"><u>nga</u>ti</span> <span class="am">kameie` +
+				`<select><option value="0">KA·me·i·e (see, see into, understand, know (spiritual sense))</option>` +
+				`<option value="1">ka·me·i·E (go)</option></select></span>.`,
+			nil,
+		},
+		{
+			lineOelNgatiKameie, HTMLRichOptions{},
+			`<span data-affixes="-l">Oel</span> <span data-affixes="-ti"><u>nga</u>ti</span> ` +
+				`<span data-translation="go" data-affixes="&lt;am&gt; &lt;ei&gt;">kamei<u>e</u></span>.`,
+			map[int]int{4: 1},
+		},
+		{
+			lineKaltxiMaFmetokyu, HTMLRichOptions{RubyIPA: true},
+			`<span><ruby>Kal<u>txì</u><rt>kalˈtʼɪ</rt></ruby></span>, <span><ruby>ma<rt>ma</rt></ruby></span> ` +
+				`<span data-affixes="-yu"><ruby><u>fme</u>tokyu<rt>ˈfmɛtok̚ju</rt></ruby></span>!`,
+			nil,
+		},
+		{
+			lineFikemIlaFyao, HTMLRichOptions{},
+			`<span>Fì<u>kem</u></span> <span class="as">ìlä</span> <span><u>fya</u>&#39;o</span>!`,
+			map[int]int{2: 2},
+		},
+		{
+			lineVolaSkeynven, HTMLRichOptions{RubyIPA: true},
+			`<span data-affixes="-a"><ruby><u>Vo</u>la<rt>ˈvola</rt></ruby></span> <span class="nm">skeynven</span>.`,
+			nil,
+		},
+		{
+			lineVolaSkeynven, HTMLRichOptions{RubyIPA: true, IPA: litxap.IPAOptions{SyllableDelimiter: ".", Fallback: true}},
+			`<span data-affixes="-a"><ruby><u>Vo</u>la<rt>ˈvo.la</rt></ruby></span> ` +
+				`<span class="nm"><ruby>skeynven<rt>skɛjn.vɛn</rt></ruby></span>.`,
+			nil,
+		},
+		{
+			lineOelNgatiKameie, HTMLRichOptions{RubyIPA: true, IPA: litxap.IPAOptions{Transcription: litxap.IPAPhonemic}},
+			`<span data-affixes="-l"><ruby>Oel<rt>wɛl</rt></ruby></span> <span data-affixes="-ti"><ruby><u>nga</u>ti<rt>ˈŋati</rt></ruby></span> ` +
+				`<span data-translation="go" data-affixes="&lt;am&gt; &lt;ei&gt;"><ruby>kamei<u>e</u><rt>kamɛiˈɛ</rt></ruby></span>.`,
+			map[int]int{4: 1},
+		},
+		{
+			lineMrrvomrr, HTMLRichOptions{RubyIPA: true},
+			`<span><ruby><span class="ss">Mrr</span>vo<u>mrr</u><rt>ˌmr̩voˈmr̩</rt></ruby></span>.`,
+			nil,
+		},
+		{
+			litxap.Line{
+				{Raw: "Neytiri", IsWord: true, Matches: []litxap.LinePartMatch{
+					{Syllables: []string{"Ney", "ti", "ri"}, Stress: 1, Entry: *litxap.ParseEntry("ney.*ti.ri: guessed: Guessed")},
+				}},
+			},
+			HTMLRichOptions{},
+			`<span class="gs" data-translation="Guessed">Ney<u>ti</u>ri</span>`,
+			nil,
+		},
+		{
+			lineKiyevaMulte, HTMLRichOptions{},
+			`<span data-affixes="-me">Kiye<u>va</u></span> <span><span class="fc"><u>mul</u></span>te</span>.`,
+			nil,
+		},
+		{
+			lineSpecialCharacters, HTMLRichOptions{},
+			`<span data-affixes="-a"><u>Vo</u>la</span> 100% &amp; {~_}`,
+			nil,
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.output, func(t *testing.T) {
+			assert.Equal(t, row.output, row.input.Format(HTMLRich(row.opts), row.selections))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package litxapformats
+
+import (
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+// LaTeX formats the line for LaTeX with \underline for the stressed syllable.
+func LaTeX() litxap.LineFormatter {
+	return LaTeXWithOptions(LaTeXOptions{})
+}
+
+// LaTeXWithOptions formats the line for LaTeX. The macros in the options are names without the
+// backslash, and must be defined in the document by the user if they're not built-in.
+func LaTeXWithOptions(opts LaTeXOptions) litxap.LineFormatter {
+	if opts.StressMacro == "" {
+		opts.StressMacro = "underline"
+	}
+
+	f := &latexFormatter{
+		stressOpen:  "\\" + opts.StressMacro + "{",
+		stressClose: "}",
+	}
+	if opts.AmbiguousMacro != "" {
+		f.amOpen = "\\" + opts.AmbiguousMacro + "{"
+	}
+	if opts.NoMatchesMacro != "" {
+		f.nmOpen = "\\" + opts.NoMatchesMacro + "{"
+	}
+	if opts.AnyStressMacro != "" {
+		f.asOpen = "\\" + opts.AnyStressMacro + "{"
+	}
+	if opts.SyllableSeparators {
+		f.separator = "\\textperiodcentered{}"
+	}
+
+	return f
+}
+
+type LaTeXOptions struct {
+	// StressMacro wraps the stressed syllable. It defaults to underline.
+	StressMacro string
+	// AmbiguousMacro wraps words with multiple matches that disagree on stress.
+	AmbiguousMacro string
+	// NoMatchesMacro wraps words without any matches.
+	NoMatchesMacro string
+	// AnyStressMacro wraps words like ìlä where any stress is fine.
+	AnyStressMacro string
+	// SyllableSeparators puts a middle dot between syllables.
+	SyllableSeparators bool
+}
+
+type latexFormatter struct {
+	stressOpen  string
+	stressClose string
+	amOpen      string
+	nmOpen      string
+	asOpen      string
+	separator   string
+}
+
+func (f *latexFormatter) LinePartTags(_ litxap.LinePart, stress int) (string, string) {
+	var open string
+	switch stress {
+	case litxap.LPSAmbiguousMatches:
+		open = f.amOpen
+	case litxap.LPSNoMatches:
+		open = f.nmOpen
+	case litxap.LPSAnyStress:
+		open = f.asOpen
+	}
+
+	if open == "" {
+		return "", ""
+	}
+
+	return open, "}"
+}
+
+func (f *latexFormatter) StressedSyllableTags() (string, string) {
+	return f.stressOpen, f.stressClose
+}
+
+func (f *latexFormatter) EscapeText(s string) string {
+	return latexEscaper.Replace(s)
+}
+
+func (f *latexFormatter) SyllableSeparator() string {
+	return f.separator
+}
+
+var latexEscaper = strings.NewReplacer(
+	"\\", "\\textbackslash{}",
+	"{", "\\{",
+	"}", "\\}",
+	"$", "\\$",
+	"&", "\\&",
+	"#", "\\#",
+	"_", "\\_",
+	"%", "\\%",
+	"~", "\\textasciitilde{}",
+	"^", "\\textasciicircum{}",
+	"<", "\\textless{}",
+	">", "\\textgreater{}",
+	"|", "\\textbar{}",
+)
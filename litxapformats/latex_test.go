@@ -0,0 +1,45 @@
+package litxapformats
+
+import (
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaTeX(t *testing.T) {
+	table := []struct {
+		input      litxap.Line
+		opts       LaTeXOptions
+		output     string
+		selections map[int]int
+	}{
+		{lineOelNgatiKameie, LaTeXOptions{}, `Oel \underline{nga}ti kameie.`, nil},
+		{lineOelNgatiKameie, LaTeXOptions{}, `Oel \underline{nga}ti \underline{ka}meie.`, map[int]int{4: 0}},
+		{lineKaltxiMaFmetokyu, LaTeXOptions{StressMacro: "textbf"}, `Kal\textbf{txì}, ma \textbf{fme}tokyu!`, nil},
+		{lineVolaSkeynven, LaTeXOptions{NoMatchesMacro: "nomatch"}, `\underline{Vo}la \nomatch{skeynven}.`, nil},
+		{
+			lineOelNgatiKameie,
+			LaTeXOptions{AmbiguousMacro: "ambiguous", SyllableSeparators: true},
+			`Oel \underline{nga}\textperiodcentered{}ti \ambiguous{kameie}.`,
+			nil,
+		},
+		{
+			lineFikemIlaFyao,
+			LaTeXOptions{AnyStressMacro: "anystress", SyllableSeparators: true},
+			`Fì\textperiodcentered{}\underline{kem} \anystress{ì\textperiodcentered{}lä} \underline{fya}\textperiodcentered{}'o!`,
+			map[int]int{2: 2},
+		},
+		{lineSpecialCharacters, LaTeXOptions{}, `\underline{Vo}la 100\% \& \{\textasciitilde{}\_\}`, nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.output, func(t *testing.T) {
+			assert.Equal(t, row.output, row.input.Format(LaTeXWithOptions(row.opts), row.selections))
+		})
+	}
+}
+
+func TestLaTeX_Defaults(t *testing.T) {
+	assert.Equal(t, `\underline{Vo}la skeynven.`, lineVolaSkeynven.Format(LaTeX(), nil))
+}
@@ -18,61 +18,90 @@ var dummyDictionary = map[string]litxap.Entry{
 	"ìlä":       *litxap.ParseEntry("*ì.lä"),
 	"ìlä:0":     *litxap.ParseEntry("ì.*lä"),
 	"fya'o":     *litxap.ParseEntry("*fya.'o"),
+	"mrrvomrr":  *litxap.ParseEntry("ˌmrr.vo.*mrr"),
+	"kiyevame":  *litxap.ParseEntry("ki.ye.*va: -me"),
+	"ulte":      *litxap.ParseEntry("*ul.te"),
 }
 
 var lineOelNgatiKameie = litxap.Line{
 	litxap.LinePart{Raw: "Oel", IsWord: true, Matches: []litxap.LinePartMatch{
-		{[]string{"Oel"}, 0, dummyDictionary["oel"], false},
+		{Syllables: []string{"Oel"}, Stress: 0, Entry: dummyDictionary["oel"]},
 	}},
 	litxap.LinePart{Raw: " "},
 	litxap.LinePart{Raw: "ngati", IsWord: true, Matches: []litxap.LinePartMatch{
-		{[]string{"nga", "ti"}, 0, dummyDictionary["ngati"], false},
+		{Syllables: []string{"nga", "ti"}, Stress: 0, Entry: dummyDictionary["ngati"]},
 	}},
 	litxap.LinePart{Raw: " "},
 	litxap.LinePart{Raw: "kameie", IsWord: true, Matches: []litxap.LinePartMatch{
-		{[]string{"ka", "me", "i", "e"}, 0, dummyDictionary["kameie"], false},
-		{[]string{"ka", "me", "i", "e"}, 3, dummyDictionary["kameie:0"], false},
+		{Syllables: []string{"ka", "me", "i", "e"}, Stress: 0, Entry: dummyDictionary["kameie"]},
+		{Syllables: []string{"ka", "me", "i", "e"}, Stress: 3, Entry: dummyDictionary["kameie:0"]},
 	}},
 	litxap.LinePart{Raw: "."},
 }
 
 var lineFikemIlaFyao = litxap.Line{
 	litxap.LinePart{Raw: "Fìkem", IsWord: true, Matches: []litxap.LinePartMatch{
-		{[]string{"Fì", "kem"}, 1, dummyDictionary["fìkem"], false},
-		{[]string{"Fì", "kem"}, 1, dummyDictionary["fìkem:0"], false},
+		{Syllables: []string{"Fì", "kem"}, Stress: 1, Entry: dummyDictionary["fìkem"]},
+		{Syllables: []string{"Fì", "kem"}, Stress: 1, Entry: dummyDictionary["fìkem:0"]},
 	}},
 	litxap.LinePart{Raw: " "},
 	litxap.LinePart{Raw: "ìlä", IsWord: true, Matches: []litxap.LinePartMatch{
-		{[]string{"ì", "lä"}, 0, dummyDictionary["ìlä"], false},
-		{[]string{"ì", "lä"}, 1, dummyDictionary["ìlä:0"], false},
+		{Syllables: []string{"ì", "lä"}, Stress: 0, Entry: dummyDictionary["ìlä"]},
+		{Syllables: []string{"ì", "lä"}, Stress: 1, Entry: dummyDictionary["ìlä:0"]},
 	}},
 	litxap.LinePart{Raw: " "},
 	litxap.LinePart{Raw: "fya'o", IsWord: true, Matches: []litxap.LinePartMatch{
-		{[]string{"fya", "'o"}, 0, dummyDictionary["fya'o"], false},
+		{Syllables: []string{"fya", "'o"}, Stress: 0, Entry: dummyDictionary["fya'o"]},
 	}},
 	litxap.LinePart{Raw: "!"},
 }
 
 var lineKaltxiMaFmetokyu = litxap.Line{
 	litxap.LinePart{Raw: "Kaltxì", IsWord: true, Matches: []litxap.LinePartMatch{
-		{[]string{"Kal", "txì"}, 1, dummyDictionary["kaltxì"], false},
+		{Syllables: []string{"Kal", "txì"}, Stress: 1, Entry: dummyDictionary["kaltxì"]},
 	}},
 	litxap.LinePart{Raw: ", "},
 	litxap.LinePart{Raw: "ma", IsWord: true, Matches: []litxap.LinePartMatch{
-		{[]string{"ma"}, 0, dummyDictionary["ma"], false},
+		{Syllables: []string{"ma"}, Stress: 0, Entry: dummyDictionary["ma"]},
 	}},
 	litxap.LinePart{Raw: " "},
 	litxap.LinePart{Raw: "fmetokyu", IsWord: true, Matches: []litxap.LinePartMatch{
-		{[]string{"fme", "tok", "yu"}, 0, dummyDictionary["fmetokyu"], false},
+		{Syllables: []string{"fme", "tok", "yu"}, Stress: 0, Entry: dummyDictionary["fmetokyu"]},
 	}},
 	litxap.LinePart{Raw: "!"},
 }
 
 var lineVolaSkeynven = litxap.Line{
 	litxap.LinePart{Raw: "Vola", IsWord: true, Matches: []litxap.LinePartMatch{
-		{[]string{"Vo", "la"}, 0, dummyDictionary["vola"], false},
+		{Syllables: []string{"Vo", "la"}, Stress: 0, Entry: dummyDictionary["vola"]},
 	}},
 	litxap.LinePart{Raw: " "},
 	litxap.LinePart{Raw: "skeynven", IsWord: true},
 	litxap.LinePart{Raw: "."},
 }
+
+var lineSpecialCharacters = litxap.Line{
+	litxap.LinePart{Raw: "Vola", IsWord: true, Matches: []litxap.LinePartMatch{
+		{Syllables: []string{"Vo", "la"}, Stress: 0, Entry: dummyDictionary["vola"]},
+	}},
+	litxap.LinePart{Raw: " 100% & {~_}"},
+}
+
+var lineMrrvomrr = litxap.Line{
+	litxap.LinePart{Raw: "Mrrvomrr", IsWord: true, Matches: []litxap.LinePartMatch{
+		{Syllables: []string{"Mrr", "vo", "mrr"}, Stress: 2, SecondaryStress: []int{0}, Entry: dummyDictionary["mrrvomrr"]},
+	}},
+	litxap.LinePart{Raw: "."},
+}
+
+// lineKiyevaMulte is "Kiyevame ulte" after litxapfilter.ElideUnstressedEWordEndings with a report.
+var lineKiyevaMulte = litxap.Line{
+	litxap.LinePart{Raw: "Kiyeva", Original: "Kiyevame", IsWord: true, Matches: []litxap.LinePartMatch{
+		{Syllables: []string{"Ki", "ye", "va"}, Stress: 2, Entry: dummyDictionary["kiyevame"]},
+	}},
+	litxap.LinePart{Raw: " "},
+	litxap.LinePart{Raw: "multe", Original: "ulte", IsWord: true, Matches: []litxap.LinePartMatch{
+		{Syllables: []string{"mul", "te"}, Stress: 0, Entry: dummyDictionary["ulte"], ChangedSyllables: []int{0}},
+	}},
+	litxap.LinePart{Raw: "."},
+}
@@ -0,0 +1,110 @@
+package litxapformats
+
+import (
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+// Typst formats the line for Typst markup with #underline for the stressed syllable.
+func Typst() litxap.LineFormatter {
+	return TypstWithOptions(TypstOptions{})
+}
+
+// TypstWithOptions formats the line for Typst markup. The functions in the options are names without
+// the #, and must be defined in the document by the user if they're not built-in.
+func TypstWithOptions(opts TypstOptions) litxap.LineFormatter {
+	if opts.StressFunction == "" {
+		opts.StressFunction = "underline"
+	}
+
+	f := &typstFormatter{
+		stressOpen: "#" + opts.StressFunction + "[",
+	}
+	if opts.AmbiguousFunction != "" {
+		f.amOpen = "#" + opts.AmbiguousFunction + "["
+	}
+	if opts.NoMatchesFunction != "" {
+		f.nmOpen = "#" + opts.NoMatchesFunction + "["
+	}
+	if opts.AnyStressFunction != "" {
+		f.asOpen = "#" + opts.AnyStressFunction + "["
+	}
+	if opts.SyllableSeparators {
+		f.separator = "·"
+	}
+
+	return f
+}
+
+type TypstOptions struct {
+	// StressFunction wraps the stressed syllable. It defaults to underline.
+	StressFunction string
+	// AmbiguousFunction wraps words with multiple matches that disagree on stress.
+	AmbiguousFunction string
+	// NoMatchesFunction wraps words without any matches.
+	NoMatchesFunction string
+	// AnyStressFunction wraps words like ìlä where any stress is fine.
+	AnyStressFunction string
+	// SyllableSeparators puts a middle dot between syllables.
+	SyllableSeparators bool
+}
+
+type typstFormatter struct {
+	stressOpen string
+	amOpen     string
+	nmOpen     string
+	asOpen     string
+	separator  string
+}
+
+func (f *typstFormatter) LinePartTags(_ litxap.LinePart, stress int) (string, string) {
+	var open string
+	switch stress {
+	case litxap.LPSAmbiguousMatches:
+		open = f.amOpen
+	case litxap.LPSNoMatches:
+		open = f.nmOpen
+	case litxap.LPSAnyStress:
+		open = f.asOpen
+	}
+
+	if open == "" {
+		return "", ""
+	}
+
+	return open, "]"
+}
+
+func (f *typstFormatter) StressedSyllableTags() (string, string) {
+	return f.stressOpen, "]"
+}
+
+func (f *typstFormatter) EscapeText(s string) string {
+	return typstEscaper.Replace(s)
+}
+
+func (f *typstFormatter) SyllableSeparator() string {
+	return f.separator
+}
+
+// typstEscaper also escapes the quotes, since Typst would otherwise turn the tìftang into a smart quote.
+var typstEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"#", "\\#",
+	"$", "\\$",
+	"*", "\\*",
+	"_", "\\_",
+	"@", "\\@",
+	"<", "\\<",
+	">", "\\>",
+	"[", "\\[",
+	"]", "\\]",
+	"`", "\\`",
+	"~", "\\~",
+	"/", "\\/",
+	"=", "\\=",
+	"+", "\\+",
+	"'", "\\'",
+	"\"", "\\\"",
+)
@@ -0,0 +1,45 @@
+package litxapformats
+
+import (
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypst(t *testing.T) {
+	table := []struct {
+		input      litxap.Line
+		opts       TypstOptions
+		output     string
+		selections map[int]int
+	}{
+		{lineOelNgatiKameie, TypstOptions{}, `Oel #underline[nga]ti kameie.`, nil},
+		{lineOelNgatiKameie, TypstOptions{}, `Oel #underline[nga]ti #underline[ka]meie.`, map[int]int{4: 0}},
+		{lineKaltxiMaFmetokyu, TypstOptions{StressFunction: "strong"}, `Kal#strong[txì], ma #strong[fme]tokyu!`, nil},
+		{lineVolaSkeynven, TypstOptions{NoMatchesFunction: "nomatch"}, `#underline[Vo]la #nomatch[skeynven].`, nil},
+		{
+			lineOelNgatiKameie,
+			TypstOptions{AmbiguousFunction: "ambiguous", SyllableSeparators: true},
+			`Oel #underline[nga]·ti #ambiguous[kameie].`,
+			nil,
+		},
+		{
+			lineFikemIlaFyao,
+			TypstOptions{AnyStressFunction: "anystress", SyllableSeparators: true},
+			`Fì·#underline[kem] #anystress[ì·lä] #underline[fya]·\'o!`,
+			map[int]int{2: 2},
+		},
+		{lineSpecialCharacters, TypstOptions{}, `#underline[Vo]la 100% & {\~\_}`, nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.output, func(t *testing.T) {
+			assert.Equal(t, row.output, row.input.Format(TypstWithOptions(row.opts), row.selections))
+		})
+	}
+}
+
+func TestTypst_Defaults(t *testing.T) {
+	assert.Equal(t, `#underline[Vo]la skeynven.`, lineVolaSkeynven.Format(Typst(), nil))
+}
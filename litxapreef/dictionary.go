@@ -0,0 +1,31 @@
+package litxapreef
+
+import (
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+// A Dictionary gives the Reef form of a Forest root word, like tùte for tute or shawm for omum. The line is still
+// looked up with a litxap.Dictionary, so this only needs the words that are different in Reef.
+type Dictionary interface {
+	// LookupReef gives an entry with the Reef root's Word, Syllables, Stress, SecondaryStress and InfixPos. The rest
+	// of the fields are ignored. It returns litxap.ErrEntryNotFound if the word is the same in Reef.
+	LookupReef(entry litxap.Entry) (litxap.Entry, error)
+}
+
+// FormDictionary is a Dictionary from Forest words to Reef forms in the same format as litxap.ParseEntry, e.g.
+// "tute": "tù.te". The entry ID is looked up before the word, so that homonyms can have different Reef forms.
+type FormDictionary map[string]string
+
+func (d FormDictionary) LookupReef(entry litxap.Entry) (litxap.Entry, error) {
+	form, ok := d[entry.ID]
+	if !ok || entry.ID == "" {
+		form, ok = d[strings.ToLower(entry.Word)]
+	}
+	if !ok {
+		return litxap.Entry{}, litxap.ErrEntryNotFound
+	}
+
+	return *litxap.ParseEntry(form), nil
+}
@@ -0,0 +1,40 @@
+package litxapreef
+
+import (
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormDictionary_LookupReef(t *testing.T) {
+	dictionary := FormDictionary{
+		"tute": "tù.te",
+		"omum": "sh·aw·m",
+		"1234": "ngeyn",
+	}
+
+	table := []struct {
+		entry    string
+		expected *litxap.Entry
+	}{
+		{"*tu.te", litxap.ParseEntry("tù.te")},
+		{"*Tu.te: -ri", litxap.ParseEntry("tù.te")},
+		{"o.*m·u·m", litxap.ParseEntry("sh·aw·m")},
+		{"fme.tok: $id:1234", litxap.ParseEntry("ngeyn")},
+		{"tu.te: $id:4321", litxap.ParseEntry("tù.te")},
+		{"kxetse", nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.entry, func(t *testing.T) {
+			res, err := dictionary.LookupReef(*litxap.ParseEntry(row.entry))
+			if row.expected == nil {
+				assert.ErrorIs(t, err, litxap.ErrEntryNotFound)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, *row.expected, res)
+			}
+		})
+	}
+}
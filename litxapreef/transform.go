@@ -0,0 +1,168 @@
+package litxapreef
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gissleh/litxap"
+	"github.com/gissleh/litxap/litxapfilter"
+	"github.com/gissleh/litxap/litxaputil"
+)
+
+// Transform renders a Forest line in Reef. It replaces the roots with their Reef forms using TransformEntries, and
+// then runs the reef-dialect preset of litxapfilter.DefaultRegistry. Since the stress comes from the Reef entries,
+// the filters that care about it will get it right. The word order is not changed, so a topical at the end of the
+// sentence must already be there in the input.
+func Transform(line litxap.Line, dict Dictionary) (litxap.Line, error) {
+	pipeline, err := litxapfilter.DefaultRegistry().Build("reef-dialect")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build reef filters: %w", err)
+	}
+
+	line, err = TransformEntries(line, dict)
+	if err != nil {
+		return nil, err
+	}
+
+	return pipeline.Apply(line), nil
+}
+
+// TransformEntries replaces the root of every match that has a Reef form in the dictionary, and makes the syllables
+// again from the changed entry. The suffixes that depend on the end of the word, like -ri and -ìri for the topical,
+// are changed to fit the Reef root. Lenition and a capital first letter are kept. The parts' Raw is changed to the
+// first match, with the text from before in Original like the filters do.
+func TransformEntries(line litxap.Line, dict Dictionary) (litxap.Line, error) {
+	newLine := slices.Clone(line)
+
+	for i, part := range newLine {
+		if !part.IsWord {
+			continue
+		}
+
+		var matches []litxap.LinePartMatch
+		for j, match := range part.Matches {
+			reef, err := dict.LookupReef(match.Entry)
+			if err != nil {
+				if errors.Is(err, litxap.ErrEntryNotFound) {
+					continue
+				}
+
+				return nil, fmt.Errorf("failed to lookup reef form of \"%s\": %w", match.Entry.Word, err)
+			}
+
+			if matches == nil {
+				matches = slices.Clone(part.Matches)
+			}
+
+			matches[j] = transformMatch(match, reef)
+		}
+		if matches == nil {
+			continue
+		}
+
+		newLine[i].Matches = matches
+
+		raw := strings.Join(matches[0].Syllables, "")
+		if raw != part.Raw && part.Original == "" {
+			newLine[i].Original = part.Raw
+		}
+		newLine[i].Raw = raw
+	}
+
+	return newLine, nil
+}
+
+func transformMatch(match litxap.LinePartMatch, reef litxap.Entry) litxap.LinePartMatch {
+	forestSyllables, _, _ := match.Entry.GenerateSyllables()
+
+	entry := match.Entry
+	entry.Word = reef.Word
+	entry.Syllables = reef.Syllables
+	entry.SecondaryStress = reef.SecondaryStress
+	entry.InfixPos = reef.InfixPos
+	if entry.Stress != -1 {
+		entry.Stress = reef.Stress
+	}
+	entry.Suffixes = fitSuffixes(entry)
+
+	syllables, stress, _, secondaryStress := entry.GenerateSyllablesWithSecondaryStress()
+	if len(syllables) == 0 || len(match.Syllables) == 0 || len(forestSyllables) == 0 {
+		return litxap.LinePartMatch{Syllables: syllables, Stress: stress, SecondaryStress: secondaryStress, Entry: entry}
+	}
+
+	// The dictionary entry doesn't know about lenition that isn't from a prefix, so it's taken from the match.
+	first := strings.ToLower(match.Syllables[0])
+	if first != forestSyllables[0] {
+		if _, lenited := litxaputil.ApplyLenition(forestSyllables[0]); lenited == first {
+			_, syllables[0] = litxaputil.ApplyLenition(syllables[0])
+		}
+	}
+
+	if fr, _ := utf8.DecodeRuneInString(match.Syllables[0]); unicode.IsUpper(fr) {
+		r, size := utf8.DecodeRuneInString(syllables[0])
+		syllables[0] = string(unicode.ToUpper(r)) + syllables[0][size:]
+	}
+
+	return litxap.LinePartMatch{
+		Syllables:       syllables,
+		Stress:          stress,
+		SecondaryStress: secondaryStress,
+		Entry:           entry,
+		StressedWord:    match.StressedWord,
+	}
+}
+
+// fitSuffixes changes the first suffix to the form that fits the end of the word, e.g. -ìri to -ri if the Reef
+// root ends with a vowel where the Forest one ended with a consonant. The later suffixes attach to the suffixes
+// before them, so they don't change.
+func fitSuffixes(entry litxap.Entry) []string {
+	if len(entry.Suffixes) == 0 {
+		return entry.Suffixes
+	}
+
+	stem := entry
+	stem.Suffixes = nil
+	syllables, _, _ := stem.GenerateSyllables()
+	if len(syllables) == 0 {
+		return entry.Suffixes
+	}
+
+	last := syllables[len(syllables)-1]
+	if strings.HasSuffix(last, "rr") || strings.HasSuffix(last, "ll") {
+		// The words ending with a pseudovowel are left as they are.
+		return entry.Suffixes
+	}
+
+	afterVowel := slices.ContainsFunc(vowelEndings, func(ending string) bool {
+		return strings.HasSuffix(last, ending)
+	})
+
+	suffixes := slices.Clone(entry.Suffixes)
+	for _, pair := range suffixPairs {
+		if afterVowel && suffixes[0] == pair[1] {
+			suffixes[0] = pair[0]
+			break
+		}
+		if !afterVowel && suffixes[0] == pair[0] {
+			suffixes[0] = pair[1]
+			break
+		}
+	}
+
+	return suffixes
+}
+
+var vowelEndings = []string{"a", "ä", "e", "é", "i", "ì", "o", "u", "ù", "ay", "ey", "aw", "ew"}
+
+// suffixPairs have the form after vowels first, and the one after consonants second.
+var suffixPairs = [][2]string{
+	{"l", "ìl"},
+	{"t", "it"},
+	{"r", "ur"},
+	{"ri", "ìri"},
+	{"yä", "ä"},
+}
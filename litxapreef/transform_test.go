@@ -0,0 +1,145 @@
+package litxapreef
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform(t *testing.T) {
+	table := []struct {
+		input    string
+		expected string
+	}{
+		{"Tuteri lu sute.", "*Tù.te.ri lu *sù.te"},
+		{"Oe omum.", "*O.e shawm"},
+		{"Pori tsyal kxetse.", "*Po.mì.ri chal *ge.tse"},
+		{"Rä'ä kämak.", "Re.*ä ke.*mak"},
+		{"Kxetse lu.", "*Ge.tse lu"},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			line, err := litxap.RunLine(row.input, dummyDictionary)
+			require.NoError(t, err)
+
+			res, err := Transform(line, dummyReefDictionary)
+			require.NoError(t, err)
+			assert.Equal(t, row.expected, formatTestLine(res))
+		})
+	}
+}
+
+func TestTransformEntries(t *testing.T) {
+	line, err := litxap.RunLine("Pori rä'ä omum.", dummyDictionary)
+	require.NoError(t, err)
+
+	res, err := TransformEntries(line, dummyReefDictionary)
+	require.NoError(t, err)
+	assert.Equal(t, "*Po.mì.ri rä.*'ä shawm", formatTestLine(res))
+	assert.Equal(t, "Pomìri", res[0].Raw)
+	assert.Equal(t, "Pori", res[0].Original)
+	assert.Equal(t, []string{"ìri"}, res[0].Matches[0].Entry.Suffixes)
+	assert.Equal(t, "rä'ä", res[2].Raw)
+	assert.Equal(t, "", res[2].Original)
+	assert.Equal(t, "Pori", line[0].Raw, "the input line should not be changed")
+
+	_, err = TransformEntries(line, failingReefDictionary{})
+	assert.ErrorIs(t, err, errFailingReefDictionary)
+}
+
+func TestFitSuffixes(t *testing.T) {
+	table := []struct {
+		entry    string
+		expected []string
+	}{
+		{"pom: -ri", []string{"ìri"}},
+		{"po: -ìri", []string{"ri"}},
+		{"tù.te: -it", []string{"t"}},
+		{"tù.te: -ti", []string{"ti"}},
+		{"shawm: -l", []string{"ìl"}},
+		{"pom: -yä", []string{"ä"}},
+		{"pom: -ìri-ri", []string{"ìri", "ri"}},
+		{"krr: -l", []string{"l"}},
+		{"hì.kay: -ìl", []string{"l"}},
+		{"pom", nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.entry, func(t *testing.T) {
+			assert.Equal(t, row.expected, fitSuffixes(*litxap.ParseEntry(row.entry)))
+		})
+	}
+}
+
+// formatTestLine writes the words of the line like litxap.ParseEntry reads them.
+func formatTestLine(line litxap.Line) string {
+	words := make([]string, 0, len(line))
+	for _, part := range line {
+		match, stress := part.SelectedMatch(-1)
+		if match == nil {
+			continue
+		}
+
+		syllables := make([]string, len(match.Syllables))
+		for i, syllable := range match.Syllables {
+			if i == stress && len(match.Syllables) > 1 {
+				syllable = "*" + syllable
+			}
+			syllables[i] = syllable
+		}
+
+		words = append(words, strings.Join(syllables, "."))
+	}
+
+	return strings.Join(words, " ")
+}
+
+type DummyDictionary map[string]string
+
+func (dictionary DummyDictionary) LookupEntries(word string) ([]litxap.Entry, error) {
+	if entries, ok := dictionary[strings.ToLower(word)]; ok {
+		lines := strings.Split(entries, "\n")
+		res := make([]litxap.Entry, 0, len(lines))
+		for _, line := range lines {
+			res = append(res, *litxap.ParseEntry(line))
+		}
+
+		return res, nil
+	}
+
+	return nil, litxap.ErrEntryNotFound
+}
+
+var dummyDictionary = DummyDictionary{
+	"tuteri": "*tu.te: -ri",
+	"lu":     "lu",
+	"rä'ä":   "rä.*'ä",
+	"oe":     "*o.e",
+	"omum":   "o.*m·u·m",
+	"sute":   "*tu.te",
+	"pori":   "po: -ri",
+	"tsyal":  "tsyal",
+	"kxetse": "*kxe.tse",
+	"kämak":  "*kä.mak",
+}
+
+// Some of these are made up to test the changes in stress and suffixes.
+var dummyReefDictionary = FormDictionary{
+	"tute":  "tù.te",
+	"omum":  "sh·aw·m",
+	"po":    "pom",
+	"kämak": "kä.*mak",
+}
+
+var errFailingReefDictionary = errors.New("dictionary is down")
+
+type failingReefDictionary struct{}
+
+func (failingReefDictionary) LookupReef(litxap.Entry) (litxap.Entry, error) {
+	return litxap.Entry{}, errFailingReefDictionary
+}
@@ -1,15 +1,33 @@
 package litxaputil
 
 import (
+	"slices"
 	"strings"
+	"unicode/utf8"
 )
 
+// GenerateNumber gives the syllables of a number from kew (0) up to 0o777777. The powers past zazam (0o10000) are not
+// canon, so they follow the pattern the community uses: vozazam is 0o100000. There is no ordinal for kew.
 func GenerateNumber(number int, ordinal bool) (syllables []string, stress int, ok bool) {
-	if number <= 0 || number > 0o77777 {
+	syllables, stress, _, ok = GenerateNumberWithSecondaryStress(number, ordinal)
+	return
+}
+
+// GenerateNumberWithSecondaryStress is GenerateNumber, but it also gives a secondary stress to the first syllable of
+// each power with its multiplier, unless it's too close to the primary stress. E.g. mrr.vo.*law gets one on mrr.
+func GenerateNumberWithSecondaryStress(number int, ordinal bool) (syllables []string, stress int, secondaryStress []int, ok bool) {
+	if number < 0 || number > MaxNumber || (number == 0 && ordinal) {
 		ok = false
 		return
 	}
 
+	if number == 0 {
+		syllables = append(syllables, numberZero)
+		stress = 0
+		ok = true
+		return
+	}
+
 	if number < 0o10 {
 		if ordinal {
 			syllables = append(syllables, numberRootsOrdinal[number]...)
@@ -21,11 +39,12 @@ func GenerateNumber(number int, ordinal bool) (syllables []string, stress int, o
 		return
 	}
 
-	for i := 4; i >= 1; i-- {
+	for i := len(numberPowerValues) - 1; i >= 1; i-- {
 		powerValue := numberPowerValues[i]
 		if number >= powerValue {
 			digit := number / powerValue
 			number %= powerValue
+			secondaryStress = append(secondaryStress, len(syllables))
 
 			if numberPrefixes[digit] != "" {
 				syllables = append(syllables, numberPrefixes[digit])
@@ -69,15 +88,25 @@ func GenerateNumber(number int, ordinal bool) (syllables []string, stress int, o
 		}
 	}
 
+	secondaryStress = SecondaryStressAwayFrom(secondaryStress, stress)
 	ok = true
 	return
 }
 
+// ParseNumber parses a number with the attributive a- or -a, or with one of the case suffixes or adpositions in
+// suffixMap, like mezamit or pukapru. The case suffixes must have the right form for the end of the number, e.g. -it
+// after a consonant.
+//
+// Plural forms are not parsed. The ay- prefix is for nouns, and it would lenite 'aw to give "ayaw", not "aylaw".
 func ParseNumber(s string) *ParseNumberResult {
-	lastPower := 100000
-	number := 0
-	ordinal := false
+	if res := parseNumberAttributive(s); res != nil {
+		return res
+	}
 
+	return parseNumberWithSuffix(s)
+}
+
+func parseNumberAttributive(s string) *ParseNumberResult {
 	prefix := ""
 	if strings.HasPrefix(s, "a") {
 		prefix = "a"
@@ -92,41 +121,123 @@ func ParseNumber(s string) *ParseNumberResult {
 		return nil
 	}
 
-	if len(strings.TrimSpace(s)) == 0 {
+	number, ordinal, ok := parseNumberParts(s, prefix != "")
+	if !ok {
 		return nil
 	}
 
+	return &ParseNumberResult{
+		Value:   number,
+		Ordinal: ordinal,
+		Prefix:  prefix,
+		Suffix:  suffix,
+	}
+}
+
+func parseNumberWithSuffix(s string) *ParseNumberResult {
+	for _, suffix := range numberSuffixNames {
+		stem, found := strings.CutSuffix(s, suffix)
+		if !found || !numberSuffixFits(stem, suffix) {
+			continue
+		}
+
+		// The ordinals are adjectives, so they don't take case.
+		number, ordinal, ok := parseNumberParts(stem, false)
+		if !ok || ordinal {
+			continue
+		}
+
+		return &ParseNumberResult{Value: number, Suffix: suffix}
+	}
+
+	return nil
+}
+
+func parseNumberParts(s string, attributivePrefix bool) (number int, ordinal bool, ok bool) {
+	lastPower := MaxNumber + 1
+
+	if len(strings.TrimSpace(s)) == 0 {
+		return 0, false, false
+	}
+
 	for len(s) > 0 {
 		part, next := ParseNumberPart(s)
 		if part == nil {
-			return nil
+			return 0, false, false
 		}
 		s = next
 
+		// Kew can't be part of a larger number
+		if part.Value() == 0 && number > 0 {
+			return 0, false, false
+		}
+
 		// Do not allow lenition
-		if part.Lenited && (number > 0 || prefix == "a") {
-			return nil
+		if part.Lenited && (number > 0 || attributivePrefix) {
+			return 0, false, false
 		}
 
 		// Ensure we only get descending powers
 		if part.Power >= lastPower {
-			return nil
+			return 0, false, false
 		}
 		lastPower = part.Power
 		number += part.Value()
 		ordinal = part.Ordinal
 
 		if part.Terminal() && len(s) > 0 {
-			return nil
+			return 0, false, false
 		}
 	}
 
-	return &ParseNumberResult{
-		Value:   number,
-		Ordinal: ordinal,
-		Prefix:  prefix,
-		Suffix:  suffix,
+	return number, ordinal, true
+}
+
+// numberSuffixFits checks that the case suffixes that come in pairs, like -l and -ìl, have the form for the end of
+// the stem, and the same for the genitive -yä and -ä. Both are allowed after pseudovowels, and after diphthongs except
+// for -yä. The ones that would make mrrr are not allowed.
+func numberSuffixFits(stem string, suffix string) bool {
+	if stem == "" {
+		return false
 	}
+	if (strings.HasSuffix(stem, "rr") && strings.HasPrefix(suffix, "r")) ||
+		(strings.HasSuffix(stem, "ll") && strings.HasPrefix(suffix, "l")) {
+		return false
+	}
+
+	afterVowel, afterConsonant := true, true
+	if !strings.HasSuffix(stem, "rr") && !strings.HasSuffix(stem, "ll") && !hasDiphthongEnding(stem) {
+		lr, _ := utf8.DecodeLastRuneInString(stem)
+		afterVowel = strings.ContainsRune("aäeéiìouù", lr)
+		afterConsonant = !afterVowel
+	}
+
+	for _, pair := range numberSuffixPairs {
+		if (suffix == pair[0] && !afterVowel) || (suffix == pair[1] && !afterConsonant) {
+			return false
+		}
+	}
+
+	// The genitive is -yä after vowels, but -ä after consonants, diphthongs, o and u.
+	plainVowel := afterVowel && !afterConsonant && !strings.HasSuffix(stem, "o") && !strings.HasSuffix(stem, "u")
+	switch suffix {
+	case "yä":
+		return plainVowel || (afterVowel && afterConsonant && !hasDiphthongEnding(stem))
+	case "ä":
+		return !plainVowel
+	}
+
+	return true
+}
+
+func hasDiphthongEnding(s string) bool {
+	for _, diphthong := range []string{"aw", "ay", "ew", "ey"} {
+		if strings.HasSuffix(s, diphthong) {
+			return true
+		}
+	}
+
+	return false
 }
 
 type NumberPart struct {
@@ -229,7 +340,8 @@ type ParseNumberResult struct {
 	Value   int
 	Ordinal bool
 	Prefix  string
-	Suffix  string
+	// Suffix is the attributive a, or the name of a suffix in suffixMap.
+	Suffix string
 }
 
 func (r *ParseNumberResult) GenerateSyllables(affixed bool) (syllables []string, stress int, ok bool) {
@@ -244,25 +356,59 @@ func (r *ParseNumberResult) GenerateSyllables(affixed bool) (syllables []string,
 			syllables[0] = r.Prefix
 		}
 		if r.Suffix != "" {
-			syllables = append(syllables, r.Suffix)
+			syllables = ApplySuffixes(syllables, []string{r.Suffix}, false)
 		}
 	}
 
 	return
 }
 
-var numberPowerValues = [5]int{0o1, 0o10, 0o100, 0o1000, 0o10000}
-var numberPowers = [5][]string{{}, {"vo", "l"}, {"za", "m"}, {"vo", "za", "m"}, {"za", "za", "m"}}
-var numberPowersClosed = [5][]string{{}, {"vol"}, {"zam"}, {"vo", "zam"}, {"za", "zam"}}
+// numberSuffixNames are the case suffixes and adpositions in suffixMap that ParseNumber accepts besides the
+// attributive -a, longest first so -ìri is tried before -ri.
+var numberSuffixNames = makeNumberSuffixNames()
+
+// numberSuffixExclusions are the suffixes in suffixMap that numbers don't take: the verb suffixes, and the genitive
+// forms other than -ä and -yä.
+var numberSuffixExclusions = []string{"yu", "tswo", "tsyìp", "fkeyk", "o", "a", "ejectiveReplacer", "e", "y", "ye"}
+
+func makeNumberSuffixNames() []string {
+	names := make([]string, 0, len(suffixMap))
+	for name := range suffixMap {
+		if !slices.Contains(numberSuffixExclusions, name) {
+			names = append(names, name)
+		}
+	}
+
+	slices.SortFunc(names, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	return names
+}
+
+// numberSuffixPairs have the form after vowels first, and the one after consonants second.
+var numberSuffixPairs = [][2]string{{"l", "ìl"}, {"t", "it"}, {"r", "ur"}, {"ri", "ìri"}}
+
+// MaxNumber is the largest number GenerateNumber and ParseNumber can handle.
+const MaxNumber = 0o777777
+
+var numberZero = "kew"
+var numberPowerValues = [6]int{0o1, 0o10, 0o100, 0o1000, 0o10000, 0o100000}
+var numberPowers = [6][]string{{}, {"vo", "l"}, {"za", "m"}, {"vo", "za", "m"}, {"za", "za", "m"}, {"vo", "za", "za", "m"}}
+var numberPowersClosed = [6][]string{{}, {"vol"}, {"zam"}, {"vo", "zam"}, {"za", "zam"}, {"vo", "za", "zam"}}
 var numberRoots = [8][]string{{}, {"'aw"}, {"mu", "ne"}, {"pxey"}, {"tsìng"}, {"mrr"}, {"pu", "kap"}, {"ki", "nä"}}
 var numberRootsOrdinal = [8][]string{{}, {"'aw", "ve"}, {"mu", "ve"}, {"pxey", "ve"}, {"tsì", "ve"}, {"mrr", "ve"}, {"pu", "ve"}, {"ki", "ve"}}
 var numberPrefixes = [8]string{"", "", "me", "pxe", "tsì", "mrr", "pu", "ki"}
 var numberPrefixesLenited = [8]string{"", "", "", "pe", "sì", "", "fu", "hi"}
 var numberSuffixes = [8][]string{{}, {"aw"}, {"mun"}, {"pey"}, {"sìng"}, {"mrr"}, {"fu"}, {"hin"}}
 var numberSuffixesOrdinal = [8][]string{{"ve"}, {"aw", "ve"}, {"mu", "ve"}, {"pey", "ve"}, {"sì", "ve"}, {"mrr", "ve"}, {"fu", "ve"}, {"hi", "ve"}}
-var numberPowersCombinedClosed = [5]string{"", "vol", "zam", "vozam", "zazam"}
-var numberPowersCombinedClosedOrdinal = [5]string{"", "volve", "zave", "vozave", "zazave"}
-var numberPowersCombined = [5]string{"", "vo", "za", "voza", "zaza"}
+var numberPowersCombinedClosed = [6]string{"", "vol", "zam", "vozam", "zazam", "vozazam"}
+var numberPowersCombinedClosedOrdinal = [6]string{"", "volve", "zave", "vozave", "zazave", "vozazave"}
+var numberPowersCombined = [6]string{"", "vo", "za", "voza", "zaza", "vozaza"}
 var numberSuffixesVol = [8]string{"l", "law", "mun", "pey", "sìng", "mrr", "fu", "hin"}
 var numberSuffixesVolOrdinal = [8]string{"lve", "lawve", "muve", "peyve", "sìve", "mrrve", "fuve", "hive"}
 var numberSuffixesZam = [8]string{"m", "maw", "mun", "pey", "sìng", "mrr", "fu", "hin"}
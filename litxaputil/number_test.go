@@ -15,7 +15,19 @@ func TestGenerateNumber(t *testing.T) {
 		resStress    int
 	}{
 		{
-			number: 0o100000, ordinal: false,
+			number: 0o1000000, ordinal: false,
+			resSyllables: "", resStress: 0,
+		},
+		{
+			number: -1, ordinal: false,
+			resSyllables: "", resStress: 0,
+		},
+		{
+			number: 0, ordinal: false,
+			resSyllables: "kew", resStress: 0,
+		},
+		{
+			number: 0, ordinal: true,
 			resSyllables: "", resStress: 0,
 		},
 		{
@@ -78,6 +90,22 @@ func TestGenerateNumber(t *testing.T) {
 			number: 0o63217, ordinal: true,
 			resSyllables: "pu.za.zam.pxe.vo.zam.me.zam.vo.hi.ve", resStress: 9,
 		},
+		{
+			number: 0o100000, ordinal: false,
+			resSyllables: "vo.za.zam", resStress: 0,
+		},
+		{
+			number: 0o100000, ordinal: true,
+			resSyllables: "vo.za.za.ve", resStress: 0,
+		},
+		{
+			number: 0o100001, ordinal: false,
+			resSyllables: "vo.za.za.maw", resStress: 3,
+		},
+		{
+			number: 0o777777, ordinal: false,
+			resSyllables: "ki.vo.za.zam.ki.za.zam.ki.vo.zam.ki.zam.ki.vo.hin", resStress: 14,
+		},
 		{
 			number: 0o5010, ordinal: false,
 			resSyllables: "mrr.vo.zam.vol", resStress: 0,
@@ -98,6 +126,33 @@ func TestGenerateNumber(t *testing.T) {
 	}
 }
 
+func TestGenerateNumberWithSecondaryStress(t *testing.T) {
+	table := []struct {
+		number          int
+		ordinal         bool
+		resSyllables    string
+		secondaryStress []int
+	}{
+		{0o6, false, "pu.kap", nil},
+		{0o11, false, "vo.law", nil},
+		{0o51, false, "mrr.vo.law", []int{0}},
+		{0o51, true, "mrr.vo.law.ve", []int{0}},
+		{0o3004, false, "pxe.vo.za.sìng", []int{0}},
+		{0o5020, false, "mrr.vo.zam.me.vol", []int{3}},
+		{0o43272, false, "tsì.za.zam.pxe.vo.zam.me.zam.ki.vo.mun", []int{0, 3, 6, 8}},
+		{0o63217, true, "pu.za.zam.pxe.vo.zam.me.zam.vo.hi.ve", []int{0, 3, 6}},
+	}
+
+	for _, row := range table {
+		t.Run(row.resSyllables, func(t *testing.T) {
+			syllables, _, secondaryStress, ok := GenerateNumberWithSecondaryStress(row.number, row.ordinal)
+			assert.True(t, ok)
+			assert.Equal(t, row.resSyllables, strings.Join(syllables, "."))
+			assert.Equal(t, row.secondaryStress, secondaryStress)
+		})
+	}
+}
+
 func TestParseNumberPart(t *testing.T) {
 	table := []struct {
 		Input string
@@ -116,6 +171,10 @@ func TestParseNumberPart(t *testing.T) {
 		{"zam", &NumberPart{Multiplier: 1, Power: 0o100}, ""},
 		{"vozam", &NumberPart{Multiplier: 1, Power: 0o1000}, ""},
 		{"zazam", &NumberPart{Multiplier: 1, Power: 0o10000}, ""},
+		{"vozazam", &NumberPart{Multiplier: 1, Power: 0o100000}, ""},
+		{"vozazave", &NumberPart{Multiplier: 1, Power: 0o100000, Ordinal: true}, ""},
+		{"kew", &NumberPart{Multiplier: 0, Power: 1}, ""},
+		{"hew", &NumberPart{Multiplier: 0, Power: 1, Lenited: true}, ""},
 		{"volve", &NumberPart{Multiplier: 1, Power: 0o10, Ordinal: true}, ""},
 		{"zave", &NumberPart{Multiplier: 1, Power: 0o100, Ordinal: true}, ""},
 		{"mevol", &NumberPart{Multiplier: 2, Power: 0o10}, ""},
@@ -128,6 +187,7 @@ func TestParseNumberPart(t *testing.T) {
 		{"kivozamkizamkivohin", &NumberPart{Multiplier: 7, Power: 0o1000, Remainder: 0}, "kizamkivohin"},
 		{"kizamkivohin", &NumberPart{Multiplier: 7, Power: 0o100, Remainder: 0}, "kivohin"},
 		{"kivohin", &NumberPart{Multiplier: 7, Power: 0o10, Remainder: 7}, ""},
+		{"mevozazampxezam", &NumberPart{Multiplier: 2, Power: 0o100000}, "pxezam"},
 	}
 
 	for _, row := range table {
@@ -154,6 +214,39 @@ func TestParseNumber(t *testing.T) {
 		{"zama", &ParseNumberResult{Value: 0o100, Suffix: "a"}},
 		{"vozama", &ParseNumberResult{Value: 0o1000, Suffix: "a"}},
 		{"azazam", &ParseNumberResult{Value: 0o10000, Prefix: "a"}},
+		{"vozazam", &ParseNumberResult{Value: 0o100000}},
+		{"mevozazamvol", &ParseNumberResult{Value: 0o200010}},
+		{"kew", &ParseNumberResult{Value: 0}},
+		{"akew", &ParseNumberResult{Value: 0, Prefix: "a"}},
+		{"kewa", &ParseNumberResult{Value: 0, Suffix: "a"}},
+		{"zamkew", nil},
+		{"mezamit", &ParseNumberResult{Value: 0o200, Suffix: "it"}},
+		{"mezamt", nil},
+		{"pukapru", &ParseNumberResult{Value: 6, Suffix: "ru"}},
+		{"fukapit", &ParseNumberResult{Value: 6, Suffix: "it"}},
+		{"munel", &ParseNumberResult{Value: 2, Suffix: "l"}},
+		{"muneìl", nil},
+		{"kewl", &ParseNumberResult{Value: 0, Suffix: "l"}},
+		{"kewìl", &ParseNumberResult{Value: 0, Suffix: "ìl"}},
+		{"mrrìri", &ParseNumberResult{Value: 5, Suffix: "ìri"}},
+		{"vozamteri", &ParseNumberResult{Value: 0o1000, Suffix: "teri"}},
+		{"mezamtseng", &ParseNumberResult{Value: 0o200, Suffix: "tseng"}},
+		{"muveri", nil},
+		{"amezamit", nil},
+		{"mezamita", nil},
+		{"muneyä", &ParseNumberResult{Value: 2, Suffix: "yä"}},
+		{"mezamä", &ParseNumberResult{Value: 0o200, Suffix: "ä"}},
+		{"pxeyä", &ParseNumberResult{Value: 3, Suffix: "ä"}},
+		{"kewìlä", &ParseNumberResult{Value: 0, Suffix: "ìlä"}},
+		{"mezamyä", nil},
+		{"muneä", nil},
+		{"pxeyyä", nil},
+		{"mezamyu", nil},
+		{"mezamtswo", nil},
+		{"munetsyìp", nil},
+		{"muneo", nil},
+		{"mezamfkeyk", nil},
+		{"vozazamvozazam", nil},
 		{"volaw", &ParseNumberResult{Value: 0o11}},
 		{"volawve", &ParseNumberResult{Value: 0o11, Ordinal: true}},
 		{"vomuve", &ParseNumberResult{Value: 0o12, Ordinal: true}},
@@ -198,9 +291,12 @@ func TestParseNumber_Exhaustive(t *testing.T) {
 		{Value: 0, Ordinal: true, Suffix: "a"},
 	}
 
-	for n := 1; n <= 0o77777; n++ {
+	for n := 0; n <= MaxNumber; n++ {
 		for i, result := range results {
 			result.Value = n
+			if n == 0 && result.Ordinal {
+				continue
+			}
 
 			syllables, _, ok := result.GenerateSyllables(true)
 			assert.True(t, ok)
@@ -214,8 +310,30 @@ func TestParseNumber_Exhaustive(t *testing.T) {
 	}
 }
 
+func TestParseNumber_Suffixes(t *testing.T) {
+	for n := 0; n <= 0o777; n++ {
+		for _, suffix := range numberSuffixNames {
+			result := ParseNumberResult{Value: n, Suffix: suffix}
+
+			syllables, _, ok := result.GenerateSyllables(true)
+			assert.True(t, ok)
+
+			word := strings.Join(syllables, "")
+			if !numberSuffixFits(strings.TrimSuffix(word, suffix), suffix) {
+				continue
+			}
+
+			assert.Equal(t, &result, ParseNumber(word))
+			if t.Failed() {
+				t.Log("Failed at:", n, suffix, strings.Join(syllables, "."))
+				return
+			}
+		}
+	}
+}
+
 func TestParseNumber_NotOk(t *testing.T) {
-	result := ParseNumberResult{Value: 0}
+	result := ParseNumberResult{Value: 0, Ordinal: true}
 	syllables, _, ok := result.GenerateSyllables(true)
 	assert.False(t, ok)
 	assert.Empty(t, syllables)
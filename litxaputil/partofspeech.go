@@ -0,0 +1,33 @@
+package litxaputil
+
+import "strings"
+
+// PartOfSpeech is the part of speech with the same abbreviations as Fwew and the dictionaries it is based on, e.g.
+// "vtr." for transitive verbs.
+type PartOfSpeech string
+
+const (
+	PoSNoun         PartOfSpeech = "n."
+	PoSProperNoun   PartOfSpeech = "n:pr."
+	PoSPronoun      PartOfSpeech = "pn."
+	PoSVerb         PartOfSpeech = "v."
+	PoSVerbTr       PartOfSpeech = "vtr."
+	PoSVerbIn       PartOfSpeech = "vin."
+	PoSAdjective    PartOfSpeech = "adj."
+	PoSAdverb       PartOfSpeech = "adv."
+	PoSAdposition   PartOfSpeech = "adp."
+	PoSNumber       PartOfSpeech = "num."
+	PoSInterjection PartOfSpeech = "intj."
+	PoSConjunction  PartOfSpeech = "conj."
+	PoSParticle     PartOfSpeech = "part."
+)
+
+// IsVerb is true for all kinds of verbs, including the modal (vim., vtrm.) and si-verbs (v:si).
+func (pos PartOfSpeech) IsVerb() bool {
+	return strings.HasPrefix(string(pos), "v")
+}
+
+// IsNoun is true for nouns, including proper nouns, pronouns and the other kinds of nouns tagged with "n:".
+func (pos PartOfSpeech) IsNoun() bool {
+	return pos == PoSNoun || pos == PoSPronoun || strings.HasPrefix(string(pos), "n:")
+}
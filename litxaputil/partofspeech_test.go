@@ -0,0 +1,33 @@
+package litxaputil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartOfSpeech(t *testing.T) {
+	table := []struct {
+		pos    PartOfSpeech
+		isVerb bool
+		isNoun bool
+	}{
+		{PoSVerbTr, true, false},
+		{"vim.", true, false},
+		{"v:si", true, false},
+		{PoSNoun, false, true},
+		{PoSProperNoun, false, true},
+		{PoSPronoun, false, true},
+		{"n:si", false, true},
+		{PoSNumber, false, false},
+		{PoSAdjective, false, false},
+		{"", false, false},
+	}
+
+	for _, row := range table {
+		t.Run(string(row.pos), func(t *testing.T) {
+			assert.Equal(t, row.isVerb, row.pos.IsVerb())
+			assert.Equal(t, row.isNoun, row.pos.IsNoun())
+		})
+	}
+}
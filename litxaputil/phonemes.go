@@ -0,0 +1,147 @@
+package litxaputil
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// PhonemeAlphabet is an ASCII phoneme alphabet that speech engines understand.
+type PhonemeAlphabet int
+
+const (
+	// PhonemesXSAMPA is X-SAMPA, with " for stress and % for secondary stress. It is also
+	// what MBROLA voices use.
+	PhonemesXSAMPA PhonemeAlphabet = iota
+	// PhonemesESpeak is eSpeak-NG's phoneme mnemonics, like the ones in [[...]] input, with ' for stress and , for
+	// secondary stress. eSpeak-NG has no ejectives, so they are written as their plain stops.
+	PhonemesESpeak
+)
+
+// SyllablesToXSAMPA is SyllablesToIPA, but for X-SAMPA. The unreleased stops are not marked.
+func SyllablesToXSAMPA(syllables []string, syllableDelimiter string, strongEmphasises []int, weakEmphasises []int) (string, error) {
+	return SyllablesToPhonemes(syllables, PhonemesXSAMPA, syllableDelimiter, strongEmphasises, weakEmphasises)
+}
+
+// SyllablesToESpeak is SyllablesToIPA, but for eSpeak-NG phoneme input.
+func SyllablesToESpeak(syllables []string, syllableDelimiter string, strongEmphasises []int, weakEmphasises []int) (string, error) {
+	return SyllablesToPhonemes(syllables, PhonemesESpeak, syllableDelimiter, strongEmphasises, weakEmphasises)
+}
+
+func SyllablesToPhonemes(syllables []string, alphabet PhonemeAlphabet, syllableDelimiter string, strongEmphasises []int, weakEmphasises []int) (string, error) {
+	res := &strings.Builder{}
+	res.Grow(len(syllables) * 6)
+	err := WriteSyllablesAsPhonemesTo(res, syllables, alphabet, syllableDelimiter, strongEmphasises, weakEmphasises)
+	return res.String(), err
+}
+
+func WriteSyllablesAsPhonemesTo(w io.StringWriter, syllables []string, alphabet PhonemeAlphabet, syllableDelimiter string, strongEmphasises []int, weakEmphasises []int) error {
+	strongMark, weakMark := `"`, "%"
+	if alphabet == PhonemesESpeak {
+		strongMark, weakMark = "'", ","
+	}
+
+	for i, syllable := range syllables {
+		// Multi-word entries have the space between words as its own syllable.
+		if strings.TrimSpace(syllable) == "" {
+			_, err := w.WriteString(syllable)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if i > 0 && syllableDelimiter != "" && strings.TrimSpace(syllables[i-1]) != "" {
+			_, err := w.WriteString(syllableDelimiter)
+			if err != nil {
+				return err
+			}
+		}
+
+		if slices.Contains(strongEmphasises, i) {
+			_, err := w.WriteString(strongMark)
+			if err != nil {
+				return err
+			}
+		} else if slices.Contains(weakEmphasises, i) {
+			_, err := w.WriteString(weakMark)
+			if err != nil {
+				return err
+			}
+		}
+
+		phonemes, err := SyllablePhonemes(syllable, alphabet)
+		if err != nil {
+			return err
+		}
+
+		for _, phoneme := range phonemes {
+			_, err := w.WriteString(phoneme)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// SyllablePhonemes splits the syllable into its phonemes in the alphabet, without any stress marks.
+func SyllablePhonemes(syllable string, alphabet PhonemeAlphabet) ([]string, error) {
+	phones, err := syllablePhones(syllable)
+	if err != nil {
+		return nil, err
+	}
+
+	table := xsampaTable
+	if alphabet == PhonemesESpeak {
+		table = espeakTable
+	}
+
+	res := make([]string, len(phones))
+	for i, phone := range phones {
+		phoneme, ok := table[phone]
+		if !ok {
+			return nil, fmt.Errorf("no phoneme for %#v in syllable %s", phone, syllable)
+		}
+
+		res[i] = phoneme
+	}
+
+	return res, nil
+}
+
+// IsVowelPhoneme is true for the phonemes that make up the body of a syllable, including the diphthongs and
+// pseudovowels.
+func IsVowelPhoneme(phoneme string, alphabet PhonemeAlphabet) bool {
+	table := xsampaTable
+	if alphabet == PhonemesESpeak {
+		table = espeakTable
+	}
+
+	for _, phone := range vowelPhones {
+		if table[phone] == phoneme {
+			return true
+		}
+	}
+
+	return false
+}
+
+var xsampaTable = map[string]string{
+	"a": "a", "ɪ": "I", "i": "i", "o": "o", "ɛ": "E", "u": "u", "æ": "{", "õ": "o~", "ʊ": "U", "ə": "@",
+	"aw": "aw", "ɛj": "Ej", "aj": "aj", "ɛw": "Ew", "r̩": "r=", "l̩": "l=",
+	"t": "t", "p": "p", "k": "k", "ʔ": "?", "n": "n", "m": "m", "ŋ": "N", "l": "l", "s": "s", "z": "z",
+	"ɾ": "4", "r": "r", "j": "j", "w": "w", "h": "h", "v": "v", "f": "f", "t͡s": "ts",
+	"tʼ": "t_>", "pʼ": "p_>", "kʼ": "k_>", "b": "b", "d": "d", "g": "g", "ʃ": "S", "tʃ": "tS",
+}
+
+var espeakTable = map[string]string{
+	"a": "a", "ɪ": "I", "i": "i", "o": "o", "ɛ": "E", "u": "u", "æ": "&", "õ": "o~", "ʊ": "U", "ə": "@",
+	"aw": "aU", "ɛj": "eI", "aj": "aI", "ɛw": "EU", "r̩": "r-", "l̩": "l-",
+	"t": "t", "p": "p", "k": "k", "ʔ": "?", "n": "n", "m": "m", "ŋ": "N", "l": "l", "s": "s", "z": "z",
+	"ɾ": "*", "r": "r", "j": "j", "w": "w", "h": "h", "v": "v", "f": "f", "t͡s": "ts",
+	"tʼ": "t", "pʼ": "p", "kʼ": "k", "b": "b", "d": "d", "g": "g", "ʃ": "S", "tʃ": "tS",
+}
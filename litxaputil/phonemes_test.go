@@ -0,0 +1,58 @@
+package litxaputil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyllablesToPhonemes(t *testing.T) {
+	table := []struct {
+		input            string
+		alphabet         PhonemeAlphabet
+		strongEmphasises []int
+		weakEmphasises   []int
+		expected         string
+	}{
+		{"fme.tok", PhonemesXSAMPA, []int{0}, nil, `"fmE.tok`},
+		{"kal.txì", PhonemesXSAMPA, []int{1}, nil, `kal."t_>I`},
+		{"mrr.vo.mrr", PhonemesXSAMPA, []int{2}, []int{0}, `%mr=.vo."mr=`},
+		{"tsyey.tsyìp", PhonemesXSAMPA, []int{0}, nil, `"tsjEj.tsjIp`},
+		{"syu.ra", PhonemesXSAMPA, []int{1}, nil, `sju."4a`},
+		{"säp.hu", PhonemesXSAMPA, []int{0}, nil, `"s{p.hu`},
+		{"oeng", PhonemesXSAMPA, nil, nil, `wEN`},
+		{"chey.chìp", PhonemesXSAMPA, []int{0}, nil, `"tSEj.tSIp`},
+		{"u.van. .si", PhonemesXSAMPA, []int{1}, []int{3}, `u."van %si`},
+		{"tə.ke.nong", PhonemesXSAMPA, []int{2}, nil, `t@.kE."noN`},
+		{"fme.tok", PhonemesESpeak, []int{0}, nil, `'fmE.tok`},
+		{"kal.txì", PhonemesESpeak, []int{1}, nil, `kal.'tI`},
+		{"mrr.vo.mrr", PhonemesESpeak, []int{2}, []int{0}, `,mr-.vo.'mr-`},
+		{"aw.ngay", PhonemesESpeak, []int{1}, nil, `aU.'NaI`},
+		{"syu.ra", PhonemesESpeak, []int{1}, nil, `sju.'*a`},
+		{"tsmu.kə.ri", PhonemesESpeak, []int{0}, nil, `'tsmu.k@.*i`},
+	}
+
+	for _, row := range table {
+		t.Run(row.expected, func(t *testing.T) {
+			res, err := SyllablesToPhonemes(strings.Split(row.input, "."), row.alphabet, ".", row.strongEmphasises, row.weakEmphasises)
+			assert.NoError(t, err)
+			assert.Equal(t, row.expected, res)
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := SyllablesToXSAMPA([]string{"tök"}, "", nil, nil)
+		assert.Error(t, err)
+		_, err = SyllablesToESpeak([]string{"tök"}, "", nil, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestIsVowelPhoneme(t *testing.T) {
+	assert.True(t, IsVowelPhoneme("E", PhonemesXSAMPA))
+	assert.True(t, IsVowelPhoneme("r=", PhonemesXSAMPA))
+	assert.True(t, IsVowelPhoneme("aI", PhonemesESpeak))
+	assert.False(t, IsVowelPhoneme("aI", PhonemesXSAMPA))
+	assert.False(t, IsVowelPhoneme("t_>", PhonemesXSAMPA))
+}
@@ -0,0 +1,209 @@
+package litxaputil
+
+import (
+	"io"
+	"slices"
+	"strings"
+)
+
+// PhoneticOptions are the options for the phonetic transcription.
+type PhoneticOptions struct {
+	// Reef voices the ejectives the same way as the Reef dialect, which is syllable-initially and
+	// before another ejective within the word.
+	Reef bool
+}
+
+func SyllablesToPhoneticIPA(syllables []string, syllableDelimiter string, strongEmphasises []int, weakEmphasises []int, prev, next string, opts PhoneticOptions) (string, error) {
+	res := &strings.Builder{}
+	res.Grow(len(syllables) * 8)
+	err := WriteSyllablesAsPhoneticIPATo(res, syllables, syllableDelimiter, strongEmphasises, weakEmphasises, prev, next, opts)
+	return res.String(), err
+}
+
+// WriteSyllablesAsPhoneticIPATo is like WriteSyllablesAsIPATo, but it writes the allophones:
+//   - Stops at the end of a syllable are unreleased unless a vowel follows.
+//   - Nasals at the end of a syllable take the place of the next consonant, or are left out before another nasal,
+//     like in litxapfilter.NasalAssimilation.
+//   - r is a flap between vowels, and a trill elsewhere.
+//   - With the Reef option, the ejectives are voiced.
+//
+// The prev and next are the last syllable of the previous word and the first syllable of the next word, so that
+// the rules can be applied across word boundaries. Leave them empty if there's a pause between the words, see
+// ContinuesClause.
+func WriteSyllablesAsPhoneticIPATo(w io.StringWriter, syllables []string, syllableDelimiter string, strongEmphasises []int, weakEmphasises []int, prev, next string, opts PhoneticOptions) error {
+	// The phones of the neighboring words are first and last, and they're only there for context.
+	phones := make([][]string, 0, len(syllables)+2)
+	phones = append(phones, contextPhones(prev))
+	for _, syllable := range syllables {
+		if strings.TrimSpace(syllable) == "" {
+			phones = append(phones, nil)
+			continue
+		}
+
+		syllablePhones, err := syllablePhones(syllable)
+		if err != nil {
+			return err
+		}
+
+		phones = append(phones, syllablePhones)
+	}
+	phones = append(phones, contextPhones(next))
+
+	applyAllophony(phones, opts)
+
+	for i, syllable := range syllables {
+		if phones[i+1] == nil {
+			_, err := w.WriteString(syllable)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if i > 0 && syllableDelimiter != "" && phones[i] != nil {
+			_, err := w.WriteString(syllableDelimiter)
+			if err != nil {
+				return err
+			}
+		}
+
+		if slices.Contains(strongEmphasises, i) {
+			_, err := w.WriteString(IPAStrongEmphasis)
+			if err != nil {
+				return err
+			}
+		} else if slices.Contains(weakEmphasises, i) {
+			_, err := w.WriteString(IPAWeakEmphasis)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, phone := range phones[i+1] {
+			_, err := w.WriteString(phone)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyAllophony changes the phones of all but the first and last syllables, which are the neighboring words.
+func applyAllophony(phones [][]string, opts PhoneticOptions) {
+	first, last := 1, len(phones)-2
+
+	if opts.Reef {
+		for i := first; i <= last; i++ {
+			if len(phones[i]) == 0 {
+				continue
+			}
+
+			// The coda goes first since it looks at the onset before it's voiced.
+			coda := phones[i][len(phones[i])-1]
+			if voiced, ok := voicedEjectives[coda]; ok && i < last && len(phones[i+1]) > 0 && len(phones[i]) > 1 {
+				if _, ok := voicedEjectives[phones[i+1][0]]; ok && phones[i+1][0] != coda {
+					phones[i][len(phones[i])-1] = voiced
+				}
+			}
+
+			if voiced, ok := voicedEjectives[phones[i][0]]; ok {
+				phones[i][0] = voiced
+			}
+		}
+	}
+
+	for i := first; i <= last; i++ {
+		if len(phones[i]) == 0 {
+			continue
+		}
+
+		nextPhone := ""
+		for _, nextPhones := range phones[i+1:] {
+			if len(nextPhones) > 0 {
+				nextPhone = nextPhones[0]
+				break
+			}
+		}
+
+		coda := len(phones[i]) - 1
+		if len(phones[i]) > 1 || !isVowelPhone(phones[i][0]) {
+			switch phones[i][coda] {
+			case "n", "m", "ŋ":
+				if slices.Contains(nasalPhones, nextPhone) && len(phones[i]) > 1 {
+					// tìng nari => tì nari
+					phones[i] = phones[i][:coda]
+				} else if nasal, ok := nasalPlaces[nextPhone]; ok {
+					phones[i][coda] = nasal
+				}
+			case "p", "t", "k":
+				if !isVowelPhone(nextPhone) {
+					phones[i][coda] += IPAUnreleased
+				}
+			}
+		}
+	}
+
+	var prevPhone string
+	for i := range phones {
+		for j, phone := range phones[i] {
+			if phone == "ɾ" && i >= first && i <= last {
+				nextPhone := ""
+				if j+1 < len(phones[i]) {
+					nextPhone = phones[i][j+1]
+				} else {
+					for _, nextPhones := range phones[i+1:] {
+						if len(nextPhones) > 0 {
+							nextPhone = nextPhones[0]
+							break
+						}
+					}
+				}
+
+				if !isVowelPhone(prevPhone) || !isVowelPhone(nextPhone) {
+					phones[i][j] = "r"
+				}
+			}
+
+			prevPhone = phone
+		}
+	}
+}
+
+// contextPhones gets the phones of a neighboring syllable, which are left out if they can't be read.
+func contextPhones(syllable string) []string {
+	if strings.TrimSpace(syllable) == "" {
+		return nil
+	}
+
+	phones, err := syllablePhones(strings.ToLower(syllable))
+	if err != nil {
+		return nil
+	}
+
+	return phones
+}
+
+func isVowelPhone(phone string) bool {
+	return slices.Contains(vowelPhones, phone)
+}
+
+var vowelPhones = []string{"a", "ɪ", "i", "o", "ɛ", "u", "æ", "õ", "ʊ", "ə", "aw", "ɛj", "aj", "ɛw", "r̩", "l̩"}
+
+var voicedEjectives = map[string]string{"pʼ": "b", "tʼ": "d", "kʼ": "g"}
+
+// ContinuesClause is true if the text between two words doesn't end the clause, which is when it has no punctuation
+// other than a comma. The sound changes across words only happen within a clause.
+func ContinuesClause(between string) bool {
+	trimmed := strings.Trim(between, "  \t\r")
+	return trimmed == "" || trimmed == ","
+}
+
+var nasalPhones = []string{"n", "m", "ŋ"}
+var nasalPlaces = map[string]string{
+	"k": "ŋ", "kʼ": "ŋ", "g": "ŋ", "ŋ": "ŋ",
+	"p": "m", "pʼ": "m", "b": "m", "m": "m",
+	"n": "n",
+}
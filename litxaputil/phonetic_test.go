@@ -0,0 +1,51 @@
+package litxaputil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyllablesToPhoneticIPA(t *testing.T) {
+	table := []struct {
+		input     string
+		delimiter string
+		stress    int
+		prev      string
+		next      string
+		opts      PhoneticOptions
+		expected  string
+	}{
+		{"fme.tok", ".", 0, "", "", PhoneticOptions{}, "ˈfmɛ.tok̚"},
+		{"fme.tok", ".", 0, "", "a", PhoneticOptions{}, "ˈfmɛ.tok"},
+		{"tsak.tap", ".", 0, "", "", PhoneticOptions{}, "ˈt͡sak̚.tap̚"},
+		{"tìng", "", -1, "", "pxi", PhoneticOptions{}, "tɪm"},
+		{"tìng", "", -1, "", "na", PhoneticOptions{}, "tɪ"},
+		{"lum.pe", ".", 0, "", "", PhoneticOptions{}, "ˈlum.pɛ"},
+		{"kan.kxa", ".", 0, "", "", PhoneticOptions{}, "ˈkaŋ.kʼa"},
+		{"san", "", -1, "", "tsyìp", PhoneticOptions{}, "san"},
+		{"syu.ra", ".", 1, "", "", PhoneticOptions{}, "sju.ˈɾa"},
+		{"rey", "", -1, "", "", PhoneticOptions{}, "rɛj"},
+		{"rey", "", -1, "a", "", PhoneticOptions{}, "ɾɛj"},
+		{"kxor", "", -1, "", "", PhoneticOptions{}, "kʼor"},
+		{"tök", "", -1, "", "", PhoneticOptions{}, ""},
+		{"txep", "", -1, "", "", PhoneticOptions{Reef: true}, "dɛp̚"},
+		{"ekx.txu", ".", 0, "", "", PhoneticOptions{Reef: true}, "ˈɛg.du"},
+		{"ekx.yu", ".", 0, "", "", PhoneticOptions{Reef: true}, "ˈɛkʼ.ju"},
+		{"un.kxa", ".", 0, "", "", PhoneticOptions{Reef: true}, "ˈuŋ.ga"},
+		{"u.van. .si", ".", 1, "", "", PhoneticOptions{}, "u.ˈvan si"},
+	}
+
+	for _, row := range table {
+		t.Run(row.expected, func(t *testing.T) {
+			res, err := SyllablesToPhoneticIPA(strings.Split(row.input, "."), row.delimiter, []int{row.stress}, []int{}, row.prev, row.next, row.opts)
+			if row.expected == "" {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, row.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package litxaputil
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PhonotacticError is a rule violation found by ValidateWord.
+type PhonotacticError struct {
+	// Position is the rune index of the violation in the word.
+	Position int
+	Rule     PhonotacticRule
+	// Text is the letters that broke the rule.
+	Text string
+	// Warning is set for irregular clusters in PhonotacticAllowIrregulars mode.
+	Warning bool
+}
+
+func (e PhonotacticError) Error() string {
+	return fmt.Sprintf("%s at %d: %#v", e.Rule, e.Position, e.Text)
+}
+
+type PhonotacticRule string
+
+const (
+	// RuleUnknownLetter is for letters that are not in the Na'vi alphabet. The word is not checked further.
+	RuleUnknownLetter PhonotacticRule = "unknown letter"
+	// RuleMissingBody is for consonants that cannot be part of any syllable, as there is no vowel for them.
+	RuleMissingBody PhonotacticRule = "missing body"
+	// RuleIllegalPreOnset is for f, s and ts before an onset that they can't be clustered with.
+	RuleIllegalPreOnset PhonotacticRule = "illegal pre-onset"
+	// RulePseudovowelWithoutOnset is for rr and ll at the start of a syllable.
+	RulePseudovowelWithoutOnset PhonotacticRule = "pseudovowel without onset"
+	// RuleIrregularCluster is for the onset clusters from loanwords and colloquial speech, like tl in tlalim.
+	RuleIrregularCluster PhonotacticRule = "irregular cluster"
+)
+
+type PhonotacticMode int
+
+const (
+	// PhonotacticStrict treats every violation as an error.
+	PhonotacticStrict PhonotacticMode = iota
+	// PhonotacticAllowIrregulars reports the irregular clusters as warnings, like SplitSyllables accepts them.
+	PhonotacticAllowIrregulars
+)
+
+// ValidateWord checks that the word follows Na'vi phonotactics, and returns every violation in order. Multi-word
+// entries separated by spaces are allowed.
+func ValidateWord(s string) []PhonotacticError {
+	return ValidateWordWithMode(s, PhonotacticStrict)
+}
+
+// ValidateWordWithMode is ValidateWord, but the mode decides how lenient it is.
+func ValidateWordWithMode(s string, mode PhonotacticMode) []PhonotacticError {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	var errs []PhonotacticError
+	for i, r := range []rune(s) {
+		if !unicode.IsSpace(r) && !strings.ContainsRune(phonotacticLetters, r) {
+			errs = append(errs, PhonotacticError{Position: i, Rule: RuleUnknownLetter, Text: string(r)})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// This works backwards like SplitSyllables, so the position of anything trimmed off is the length of
+	// what's left.
+	addError := func(rule PhonotacticRule, text string) {
+		errs = append(errs, PhonotacticError{Position: utf8.RuneCountInString(s), Rule: rule, Text: text})
+	}
+
+	var last *Syllable
+	for len(s) > 0 {
+		curr := Syllable{}
+
+		for _, coda := range codas {
+			if strings.HasSuffix(s, coda) {
+				if coda == "r" && strings.HasSuffix(s, "rr") && !strings.HasSuffix(s, "rrr") {
+					break
+				}
+				if coda == "l" && strings.HasSuffix(s, "ll") && !strings.HasSuffix(s, "lll") {
+					break
+				}
+
+				curr.Coda = coda
+				s = strings.TrimSuffix(s, coda)
+				break
+			}
+		}
+
+		foundBody := false
+		for _, body := range bodies {
+			if strings.HasSuffix(s, body) {
+				curr.Body = body
+				s = strings.TrimSuffix(s, body)
+				foundBody = true
+				break
+			}
+		}
+
+		if !foundBody {
+			if curr.Coda != "" && last != nil && last.PreOnset == "" && last.Irregular == "" && last.Onset != "" {
+				rule := PhonotacticError{
+					Position: utf8.RuneCountInString(s),
+					Rule:     RuleIrregularCluster,
+					Text:     curr.Coda + last.Onset,
+					Warning:  mode == PhonotacticAllowIrregulars,
+				}
+				errs = append(errs, rule)
+
+				last.Irregular = last.Onset
+				last.Onset = curr.Coda
+				continue
+			}
+
+			if curr.Coda == "" {
+				curr.Coda = trimPhonotacticConsonant(&s)
+			}
+
+			addError(RuleMissingBody, curr.Coda)
+			continue
+		}
+
+		for _, onset := range onsets {
+			if strings.HasSuffix(s, onset) {
+				curr.Onset = onset
+				s = strings.TrimSuffix(s, onset)
+				break
+			}
+		}
+
+		for _, preOnset := range preOnsets {
+			if strings.HasSuffix(s, preOnset) {
+				s = strings.TrimSuffix(s, preOnset)
+				curr.PreOnset = preOnset
+
+				allowed := onsetsAfterPreS
+				if preOnset == "f" {
+					allowed = onsetsAfterPreF
+				}
+				if !slices.Contains(allowed, curr.Onset) {
+					addError(RuleIllegalPreOnset, preOnset+curr.Onset)
+				}
+
+				break
+			}
+		}
+
+		if (curr.Body == "rr" || curr.Body == "ll") && curr.Onset == "" {
+			errs = append(errs, PhonotacticError{
+				Position: utf8.RuneCountInString(s) + utf8.RuneCountInString(curr.PreOnset),
+				Rule:     RulePseudovowelWithoutOnset,
+				Text:     curr.Body,
+			})
+		}
+
+		s = strings.TrimRight(s, " \t\r\n ")
+		last = &curr
+	}
+
+	slices.SortStableFunc(errs, func(a, b PhonotacticError) int {
+		return a.Position - b.Position
+	})
+
+	return errs
+}
+
+// HasPhonotacticFailures is true if any of the errors is not a warning.
+func HasPhonotacticFailures(errs []PhonotacticError) bool {
+	for _, err := range errs {
+		if !err.Warning {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trimPhonotacticConsonant trims off the longest consonant at the end of s, or just a rune if there's none.
+func trimPhonotacticConsonant(s *string) string {
+	longest := ""
+	for _, consonant := range slices.Concat(onsets, preOnsets, codas) {
+		if len(consonant) > len(longest) && strings.HasSuffix(*s, consonant) {
+			longest = consonant
+		}
+	}
+	if longest == "" {
+		_, size := utf8.DecodeLastRuneInString(*s)
+		longest = (*s)[len(*s)-size:]
+	}
+
+	*s = strings.TrimSuffix(*s, longest)
+	return longest
+}
+
+const phonotacticLetters = "'aäbdeéfghiìklmnoõprstuùvwxyz"
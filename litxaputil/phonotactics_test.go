@@ -0,0 +1,60 @@
+package litxaputil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateWord(t *testing.T) {
+	table := []struct {
+		input    string
+		mode     PhonotacticMode
+		expected []PhonotacticError
+	}{
+		{"fmetokyu", PhonotacticStrict, nil},
+		{"Prrkxentrrkrr", PhonotacticStrict, nil},
+		{"uvan si", PhonotacticStrict, nil},
+		{"tlalim", PhonotacticStrict, []PhonotacticError{{Position: 0, Rule: RuleIrregularCluster, Text: "tl"}}},
+		{"tlalim", PhonotacticAllowIrregulars, []PhonotacticError{{Position: 0, Rule: RuleIrregularCluster, Text: "tl", Warning: true}}},
+		{"mangkwan", PhonotacticAllowIrregulars, []PhonotacticError{{Position: 4, Rule: RuleIrregularCluster, Text: "kw", Warning: true}}},
+		{"kramtran", PhonotacticStrict, []PhonotacticError{
+			{Position: 0, Rule: RuleIrregularCluster, Text: "kr"},
+			{Position: 4, Rule: RuleIrregularCluster, Text: "tr"},
+		}},
+		{"svane", PhonotacticStrict, []PhonotacticError{{Position: 0, Rule: RuleIllegalPreOnset, Text: "sv"}}},
+		{"tafha", PhonotacticStrict, []PhonotacticError{{Position: 2, Rule: RuleIllegalPreOnset, Text: "fh"}}},
+		{"rr", PhonotacticStrict, []PhonotacticError{{Position: 0, Rule: RulePseudovowelWithoutOnset, Text: "rr"}}},
+		{"maerr", PhonotacticStrict, []PhonotacticError{{Position: 3, Rule: RulePseudovowelWithoutOnset, Text: "rr"}}},
+		{"keln", PhonotacticStrict, []PhonotacticError{{Position: 3, Rule: RuleMissingBody, Text: "n"}}},
+		{"ehanis", PhonotacticStrict, []PhonotacticError{{Position: 5, Rule: RuleMissingBody, Text: "s"}}},
+		{"klreytu'um", PhonotacticAllowIrregulars, []PhonotacticError{
+			{Position: 0, Rule: RuleMissingBody, Text: "k"},
+			{Position: 1, Rule: RuleIrregularCluster, Text: "lr", Warning: true},
+		}},
+		{"Jace Sully", PhonotacticStrict, []PhonotacticError{
+			{Position: 0, Rule: RuleUnknownLetter, Text: "j"},
+			{Position: 2, Rule: RuleUnknownLetter, Text: "c"},
+		}},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			assert.Equal(t, row.expected, ValidateWordWithMode(row.input, row.mode))
+			if row.mode == PhonotacticStrict {
+				assert.Equal(t, row.expected, ValidateWord(row.input))
+			}
+		})
+	}
+}
+
+func TestHasPhonotacticFailures(t *testing.T) {
+	assert.False(t, HasPhonotacticFailures(nil))
+	assert.False(t, HasPhonotacticFailures(ValidateWordWithMode("tlalim", PhonotacticAllowIrregulars)))
+	assert.True(t, HasPhonotacticFailures(ValidateWord("tlalim")))
+	assert.True(t, HasPhonotacticFailures(ValidateWordWithMode("svane", PhonotacticAllowIrregulars)))
+}
+
+func TestPhonotacticError_Error(t *testing.T) {
+	assert.Equal(t, `illegal pre-onset at 0: "sv"`, PhonotacticError{Rule: RuleIllegalPreOnset, Text: "sv"}.Error())
+}
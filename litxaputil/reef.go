@@ -0,0 +1,114 @@
+package litxaputil
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ForestSpellings gives the spellings a Reef word could have in Forest Na'vi, so it can be looked up in a
+// dictionary. It reverses what MatchSyllables tolerates: sh and ch are sy and tsy, ù is u, and b, d, g and e may be
+// px, tx, kx and ä. The word itself is not included, and at most limit spellings are returned with the ones with the
+// fewest changes first.
+func ForestSpellings(word string, limit int) []string {
+	if limit <= 0 {
+		return nil
+	}
+
+	word = strings.ToLower(word)
+
+	// The segments are the parts of the word, and the optional ones are the indices of those with a second spelling.
+	var segments [][2]string
+	var optional []int
+	for pos := 0; pos < len(word); {
+		segment, alt, size := forestSpelling(word, pos)
+		if alt != "" {
+			optional = append(optional, len(segments))
+		}
+
+		segments = append(segments, [2]string{segment, alt})
+		pos += size
+	}
+
+	var res []string
+	spell := func(changed []int) bool {
+		sb := strings.Builder{}
+		sb.Grow(len(word) + len(changed))
+		for i, segment := range segments {
+			if len(changed) > 0 && changed[0] == i {
+				sb.WriteString(segment[1])
+				changed = changed[1:]
+			} else {
+				sb.WriteString(segment[0])
+			}
+		}
+
+		if spelling := sb.String(); spelling != word {
+			res = append(res, spelling)
+		}
+
+		return len(res) < limit
+	}
+
+	for n := 0; n <= len(optional); n++ {
+		if !eachCombination(optional, n, spell) {
+			break
+		}
+	}
+
+	return res
+}
+
+// forestSpelling gives the Forest spelling of the segment at pos, and an alternative if it's ambiguous.
+func forestSpelling(word string, pos int) (string, string, int) {
+	rest := word[pos:]
+
+	for i, alt := range reefSyTsysAlts {
+		if strings.HasPrefix(rest, alt) {
+			return reefSyTsys[i], "", len(alt)
+		}
+	}
+
+	for i, alt := range ejectiveAlts {
+		// The g in ng is never an ejective.
+		if strings.HasPrefix(rest, alt) && !(alt == "g" && strings.HasSuffix(word[:pos], "n")) {
+			return alt, ejectives[i], len(alt)
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "ù"):
+		return "u", "", len("ù")
+	case strings.HasPrefix(rest, "ey"), strings.HasPrefix(rest, "ew"):
+		// There are no äy and äw diphthongs.
+		return rest[:2], "", 2
+	case strings.HasPrefix(rest, "e"):
+		return "e", "ä", len("e")
+	}
+
+	_, size := utf8.DecodeRuneInString(rest)
+	return rest[:size], "", size
+}
+
+// eachCombination calls cb with every combination of n items in order, until it returns false.
+func eachCombination(items []int, n int, cb func(combination []int) bool) bool {
+	combination := make([]int, 0, n)
+
+	var recurse func(start int) bool
+	recurse = func(start int) bool {
+		if len(combination) == n {
+			return cb(combination)
+		}
+
+		for i := start; i <= len(items)-(n-len(combination)); i++ {
+			combination = append(combination, items[i])
+			if !recurse(i + 1) {
+				return false
+			}
+			combination = combination[:len(combination)-1]
+		}
+
+		return true
+	}
+
+	return recurse(0)
+}
@@ -0,0 +1,32 @@
+package litxaputil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForestSpellings(t *testing.T) {
+	table := []struct {
+		word     string
+		limit    int
+		expected []string
+	}{
+		{"getse", 16, []string{"kxetse", "gätse", "getsä", "kxätse", "kxetsä", "gätsä", "kxätsä"}},
+		{"getse", 3, []string{"kxetse", "gätse", "getsä"}},
+		{"Chal", 16, []string{"tsyal"}},
+		{"shawm", 16, []string{"syawm"}},
+		{"tùte", 16, []string{"tute", "tutä"}},
+		{"ngeyn", 16, nil},
+		{"sekeynven", 16, []string{"säkeynven", "sekeynvän", "säkeynvän"}},
+		{"dodo", 2, []string{"txodo", "dotxo"}},
+		{"dodo", 0, nil},
+		{"kaltxì", 16, nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.word, func(t *testing.T) {
+			assert.Equal(t, row.expected, ForestSpellings(row.word, row.limit))
+		})
+	}
+}
@@ -17,12 +17,19 @@ import (
 // RomanizeIPA generates a spelling based on the phonetics.
 // The returned value is a list of words of syllables and a list of words' stresses.
 func RomanizeIPA(IPA string) ([][][]string, [][]int) {
+	results, stressMarkers, _ := RomanizeIPAWithSecondaryStress(IPA)
+	return results, stressMarkers
+}
+
+// RomanizeIPAWithSecondaryStress is RomanizeIPA, but it also returns the secondary stresses (ˌ) of each word.
+func RomanizeIPAWithSecondaryStress(IPA string) ([][][]string, [][]int, [][][]int) {
 	// Special case: empty string
 	if len(strings.Trim(IPA, " []")) < 1 {
-		return [][][]string{}, [][]int{}
+		return [][][]string{}, [][]int{}, [][][]int{}
 	}
 
 	stressMarkers := make([][]int, 0, 2)
+	secondaryStressMarkers := make([][][]int, 0, 2)
 
 	// now Romanize the IPA
 	IPA = strings.ReplaceAll(IPA, "ʊ", "u")
@@ -39,6 +46,7 @@ func RomanizeIPA(IPA string) ([][][]string, [][]int) {
 	}
 
 	stressMarkers = append(stressMarkers, []int{})
+	secondaryStressMarkers = append(secondaryStressMarkers, [][]int{})
 
 	// get the last one only
 	for j := 0; j < len(word); j++ {
@@ -47,10 +55,13 @@ func RomanizeIPA(IPA string) ([][][]string, [][]int) {
 			bigResults = append(bigResults, results)
 			results = [][]string{{}}
 			stressMarkers = append(stressMarkers, []int{})
+			secondaryStressMarkers = append(secondaryStressMarkers, [][]int{})
 			continue
 		}
 
 		stressMarkers[len(stressMarkers)-1] = append(stressMarkers[len(stressMarkers)-1], -1)
+		secondaryStressMarkers[len(secondaryStressMarkers)-1] = append(secondaryStressMarkers[len(secondaryStressMarkers)-1], nil)
+		secondaryStresses := &secondaryStressMarkers[len(secondaryStressMarkers)-1][len(secondaryStressMarkers[len(secondaryStressMarkers)-1])-1]
 
 		syllables := strings.Split(word[j], ".")
 
@@ -71,6 +82,9 @@ func RomanizeIPA(IPA string) ([][][]string, [][]int) {
 				everStressed = true
 				stressMarkers[len(stressMarkers)-1][len(stressMarkers[len(stressMarkers)-1])-1] = k
 			}
+			if strings.Contains(syllables[k], IPAWeakEmphasis) {
+				*secondaryStresses = append(*secondaryStresses, k)
+			}
 
 			r1, r1s := utf8.DecodeRuneInString(syllable)
 
@@ -182,7 +196,7 @@ func RomanizeIPA(IPA string) ([][][]string, [][]int) {
 
 	bigResults = append(bigResults, results)
 
-	return bigResults, stressMarkers
+	return bigResults, stressMarkers, secondaryStressMarkers
 }
 
 var romanizaionTable = map[string]string{
@@ -190,6 +204,7 @@ var romanizaionTable = map[string]string{
 	"a": "a", "i": "i", "ɪ": "ì",
 	"o": "o", "ɛ": "e", "u": "u",
 	"æ": "ä", "õ": "õ", //võvä' only
+	"ə": "ə", // reduced ì in fast speech
 	// Diphthongs
 	"aw": "aw", "ɛj": "ey",
 	"aj": "ay", "ɛw": "ew",
@@ -218,11 +233,36 @@ func SyllableToIPA(syllable string) (string, error) {
 }
 
 func WriteSyllableAsIPATo(w io.StringWriter, syllable string) error {
+	phones, err := syllablePhones(syllable)
+	if err != nil {
+		return err
+	}
+
+	for i, phone := range phones {
+		_, err := w.WriteString(phone)
+		if err != nil {
+			return err
+		}
+
+		if i == len(phones)-1 && (phone == "k" || phone == "p" || phone == "t") {
+			_, err := w.WriteString(IPAUnreleased)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// syllablePhones splits the syllable into the IPA of each sound in it.
+func syllablePhones(syllable string) ([]string, error) {
 	// Odd edge cases
 	if edgeCase, ok := syllableEdgeCases[syllable]; ok {
 		syllable = edgeCase
 	}
 
+	phones := make([]string, 0, len(syllable))
 	current := syllable
 	for current != "" {
 		// ts = ["t", "ts"]
@@ -245,32 +285,17 @@ func WriteSyllableAsIPATo(w io.StringWriter, syllable string) error {
 		}
 
 		if tableKeys[1] != "" && romanizaionTableReverse[tableKeys[1]] != "" {
-			_, err := w.WriteString(romanizaionTableReverse[tableKeys[1]])
-			if err != nil {
-				return err
-			}
-
+			phones = append(phones, romanizaionTableReverse[tableKeys[1]])
 			current = strings.TrimPrefix(current, tableKeys[1])
 		} else if tableKeys[0] != "" && romanizaionTableReverse[tableKeys[0]] != "" {
-			_, err := w.WriteString(romanizaionTableReverse[tableKeys[0]])
-			if err != nil {
-				return err
-			}
-
-			if current == "k" || current == "p" || current == "t" {
-				_, err := w.WriteString("̚")
-				if err != nil {
-					return err
-				}
-			}
-
+			phones = append(phones, romanizaionTableReverse[tableKeys[0]])
 			current = strings.TrimPrefix(current, tableKeys[0])
 		} else {
-			return fmt.Errorf("unknown symbols [%#v, %#v] in syllable %s", tableKeys[0], tableKeys[1], syllable)
+			return nil, fmt.Errorf("unknown symbols [%#v, %#v] in syllable %s", tableKeys[0], tableKeys[1], syllable)
 		}
 	}
 
-	return nil
+	return phones, nil
 }
 
 var syllableEdgeCases = map[string]string{
@@ -291,10 +316,21 @@ func SyllablesToIPA(syllables []string, syllableDelimiter string, strongEmphasis
 
 const IPAStrongEmphasis = "ˈ"
 const IPAWeakEmphasis = "ˌ"
+const IPAUnreleased = "\u031a"
 
 func WriteSyllablesAsIPATo(w io.StringWriter, syllables []string, syllableDelimiter string, strongEmphasises []int, weakEmphasises []int) error {
 	for i, syllable := range syllables {
-		if i > 0 && syllableDelimiter != "" {
+		// Multi-word entries have the space between words as its own syllable.
+		if strings.TrimSpace(syllable) == "" {
+			_, err := w.WriteString(syllable)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if i > 0 && syllableDelimiter != "" && strings.TrimSpace(syllables[i-1]) != "" {
 			_, err := w.WriteString(syllableDelimiter)
 			if err != nil {
 				return err
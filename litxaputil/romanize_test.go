@@ -81,6 +81,30 @@ func TestRomanizeIPA(t *testing.T) {
 	}
 }
 
+func TestRomanizeIPAWithSecondaryStress(t *testing.T) {
+	table := []struct {
+		curr      string
+		secondary [][][]int
+	}{
+		{"ɛ", [][][]int{{nil}}},
+		{"tɪ.ˈfmɛ.tok̚", [][][]int{{nil}}},
+		{"ˌmɛ.o.a.u.ni.a.ˈɛ.a", [][][]int{{{0}}}},
+		{"ˈnɪ.ˌju ˈjoɾ.kɪ", [][][]int{{{1}, nil}}},
+		{"ˌmṛ.vo.ˈlaw] or [ˌmṛ.ˌvo.ˈlaw", [][][]int{{{0}}, {{0, 1}}}},
+		{"", [][][]int{}},
+	}
+
+	for _, row := range table {
+		t.Run(row.curr, func(t *testing.T) {
+			spelling, stress, secondary := RomanizeIPAWithSecondaryStress(row.curr)
+			expectedSpelling, expectedStress := RomanizeIPA(row.curr)
+			assert.Equal(t, expectedSpelling, spelling)
+			assert.Equal(t, expectedStress, stress)
+			assert.Equal(t, row.secondary, secondary)
+		})
+	}
+}
+
 func TestSyllableToIPA(t *testing.T) {
 	table := []struct {
 		input    string
@@ -97,6 +121,7 @@ func TestSyllableToIPA(t *testing.T) {
 		{"on", "on"},
 		{"u", "u"},
 		{"van", "van"},
+		{"tə", "tə"},
 	}
 
 	for _, row := range table {
@@ -129,6 +154,7 @@ func TestSyllablesToIPA(t *testing.T) {
 		{"oe.nge.yä", ".", []int{0}, []int{}, "ˈwɛ.ŋɛ.jæ"},
 		{"zaw.prr.te'", "-", []int{1}, []int{}, "zaw-ˈpr̩-tɛʔ"},
 		{"me.o.a.u.ni.a.e.a", "", []int{6}, []int{0}, "ˌmɛoauniaˈɛa"},
+		{"u.van. .si", ".", []int{1}, []int{3}, "u.ˈvan ˌsi"},
 	}
 
 	for _, row := range table {
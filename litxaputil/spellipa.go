@@ -0,0 +1,238 @@
+package litxaputil
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SpelledIPAPart is a word or the text between words from SpellIPA.
+type SpelledIPAPart struct {
+	// Raw is the spelling of the first alternative for words, and the text as-is for the rest.
+	Raw string
+	// IPA is the part of the input the part was made from.
+	IPA    string
+	IsWord bool
+	// Alternatives has the pronunciations of the word, which is more than one if they were separated with "or".
+	Alternatives []SpelledIPAWord
+	// Position is the byte offset of the part in the input.
+	Position int
+}
+
+type SpelledIPAWord struct {
+	Syllables []string
+	// Stress is -1 if no syllable was marked, unless there's only one syllable.
+	Stress          int
+	SecondaryStress []int
+}
+
+// UnknownIPASymbol is a symbol SpellIPA could not read, with the byte offset of it in the input.
+type UnknownIPASymbol struct {
+	Symbol   string
+	Position int
+}
+
+// UnknownIPASymbolsError is returned by SpellIPA along with the parts it could read.
+type UnknownIPASymbolsError struct {
+	Symbols []UnknownIPASymbol
+}
+
+func (e *UnknownIPASymbolsError) Error() string {
+	sb := &strings.Builder{}
+	sb.WriteString("unknown IPA symbols:")
+	for i, symbol := range e.Symbols {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+
+		sb.WriteString(fmt.Sprintf(" %#v at %d", symbol.Symbol, symbol.Position))
+	}
+
+	return sb.String()
+}
+
+// SpellIPA spells an IPA text in the Na'vi alphabet, like the one made by litxap.Line.IPA or the dictionary
+// entries' IPA. The words are split into syllables at the stress marks and syllable delimiters (. and ·), and
+// with SplitSyllables between them. Alternatives written as "[...] or [...]" become one word. The symbols it
+// cannot read are left out, and an *UnknownIPASymbolsError is returned with the rest of the result.
+func SpellIPA(ipa string) ([]SpelledIPAPart, error) {
+	var parts []SpelledIPAPart
+	var unknown []UnknownIPASymbol
+	var segments []ipaSegment
+
+	partStart := 0
+	inWord := false
+
+	endPart := func(end int) {
+		if end <= partStart {
+			return
+		}
+
+		part := SpelledIPAPart{IPA: ipa[partStart:end], Position: partStart, IsWord: inWord}
+		if inWord {
+			word := spelledIPAWord(segments)
+			part.Raw = strings.Join(word.Syllables, "")
+			part.Alternatives = []SpelledIPAWord{word}
+			segments = segments[:0]
+		} else {
+			part.Raw = part.IPA
+		}
+
+		parts = append(parts, part)
+		partStart = end
+	}
+	startWord := func(pos int) {
+		if !inWord {
+			endPart(pos)
+			inWord = true
+		}
+	}
+
+	for pos := 0; pos < len(ipa); {
+		r, size := utf8.DecodeRuneInString(ipa[pos:])
+
+		switch {
+		case r == 'ˈ' || r == 'ˌ':
+			startWord(pos)
+			segments = append(segments, ipaSegment{stress: r})
+		case (r == '.' || r == '·') && inWord && pos+size < len(ipa) && isIPAWordRune(ipa[pos+size:]):
+			segments = append(segments, ipaSegment{})
+		case r == '̚':
+			// The unreleased stops are spelled the same.
+		default:
+			if spelling, n := nextIPASpelling(ipa[pos:]); n > 0 {
+				startWord(pos)
+				if len(segments) == 0 {
+					segments = append(segments, ipaSegment{})
+				}
+				segments[len(segments)-1].spelling += spelling
+				size = n
+			} else if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+				if inWord {
+					endPart(pos)
+					inWord = false
+				}
+			} else {
+				unknown = append(unknown, UnknownIPASymbol{Symbol: string(r), Position: pos})
+			}
+		}
+
+		pos += size
+	}
+	endPart(len(ipa))
+
+	parts = mergeIPAAlternatives(parts)
+
+	if len(unknown) > 0 {
+		return parts, &UnknownIPASymbolsError{Symbols: unknown}
+	}
+
+	return parts, nil
+}
+
+type ipaSegment struct {
+	spelling string
+	stress   rune
+}
+
+// spelledIPAWord splits the segments between the stress marks and delimiters into syllables.
+func spelledIPAWord(segments []ipaSegment) SpelledIPAWord {
+	word := SpelledIPAWord{Stress: -1}
+	for _, segment := range segments {
+		if segment.spelling == "" {
+			continue
+		}
+
+		syllables := []string{segment.spelling}
+		if split := SplitSyllables(segment.spelling); split != nil {
+			syllables = syllables[:0]
+			for _, syllable := range split {
+				syllables = append(syllables, syllable.PreOnset+syllable.Onset+syllable.Irregular+syllable.Body+syllable.Coda)
+			}
+		}
+
+		switch segment.stress {
+		case 'ˈ':
+			if word.Stress == -1 {
+				word.Stress = len(word.Syllables)
+			}
+		case 'ˌ':
+			word.SecondaryStress = append(word.SecondaryStress, len(word.Syllables))
+		}
+
+		word.Syllables = append(word.Syllables, syllables...)
+	}
+
+	if len(word.Syllables) == 1 && word.Stress == -1 {
+		word.Stress = 0
+	}
+
+	return word
+}
+
+// mergeIPAAlternatives turns word, "] ", or, " [", word into one word with two alternatives.
+func mergeIPAAlternatives(parts []SpelledIPAPart) []SpelledIPAPart {
+	res := parts[:0]
+	for i := 0; i < len(parts); i++ {
+		part := parts[i]
+		for part.IsWord && i+4 < len(parts) &&
+			strings.TrimSpace(parts[i+1].IPA) == "]" && parts[i+2].IPA == "or" &&
+			strings.TrimSpace(parts[i+3].IPA) == "[" && parts[i+4].IsWord {
+			part.Alternatives = append(part.Alternatives, parts[i+4].Alternatives...)
+			part.IPA = part.IPA + parts[i+1].IPA + parts[i+2].IPA + parts[i+3].IPA + parts[i+4].IPA
+			i += 4
+		}
+
+		res = append(res, part)
+	}
+
+	return res
+}
+
+// nextIPASpelling finds the longest IPA symbol at the start of s, and returns its spelling and length.
+func nextIPASpelling(s string) (string, int) {
+	for n := ipaSpellingMaxRunes; n > 0; n-- {
+		end := 0
+		for i := 0; i < n && end < len(s); i++ {
+			_, size := utf8.DecodeRuneInString(s[end:])
+			end += size
+		}
+
+		if spelling, ok := ipaSpellingTable[s[:end]]; ok {
+			return spelling, end
+		}
+	}
+
+	return "", 0
+}
+
+func isIPAWordRune(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	if r == 'ˈ' || r == 'ˌ' {
+		return true
+	}
+
+	_, n := nextIPASpelling(s)
+	return n > 0
+}
+
+const ipaSpellingMaxRunes = 3
+
+var ipaSpellingTable = func() map[string]string {
+	table := make(map[string]string, len(romanizaionTable)+16)
+	for ipa, spelling := range romanizaionTable {
+		if strings.TrimSpace(ipa) != "" && spelling != "" {
+			table[ipa] = spelling
+		}
+	}
+
+	// The symbols used by SyllablesToIPA and the phonetic transcription.
+	table["tʼ"] = "tx"
+	table["pʼ"] = "px"
+	table["kʼ"] = "kx"
+	table["r̩"] = "rr"
+	table["l̩"] = "ll"
+
+	return table
+}()
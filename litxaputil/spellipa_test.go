@@ -0,0 +1,101 @@
+package litxaputil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpellIPA(t *testing.T) {
+	table := []struct {
+		input   string
+		raw     []string
+		words   [][]SpelledIPAWord
+		unknown []UnknownIPASymbol
+	}{
+		{
+			"kalˈtʼɪ, ma ˈfmɛtok̚ju!",
+			[]string{"kaltxì", ", ", "ma", " ", "fmetokyu", "!"},
+			[][]SpelledIPAWord{
+				{{Syllables: []string{"kal", "txì"}, Stress: 1}}, nil,
+				{{Syllables: []string{"ma"}, Stress: 0}}, nil,
+				{{Syllables: []string{"fme", "tok", "yu"}, Stress: 0}}, nil,
+			},
+			nil,
+		},
+		{
+			"fɪ.ˈkɛm ɪ.læ ˈfja.ʔo.",
+			[]string{"fìkem", " ", "ìlä", " ", "fya'o", "."},
+			[][]SpelledIPAWord{
+				{{Syllables: []string{"fì", "kem"}, Stress: 1}}, nil,
+				{{Syllables: []string{"ì", "lä"}, Stress: -1}}, nil,
+				{{Syllables: []string{"fya", "'o"}, Stress: 0}}, nil,
+			},
+			nil,
+		},
+		{
+			"[aj.ˈfo] or [ˈaj.fo]",
+			[]string{"[", "ayfo", "]"},
+			[][]SpelledIPAWord{
+				nil,
+				{{Syllables: []string{"ay", "fo"}, Stress: 1}, {Syllables: []string{"ay", "fo"}, Stress: 0}},
+				nil,
+			},
+			nil,
+		},
+		{
+			"ˌmr̩voˈmr̩ ˈfmɛtok̚ˌmuŋwr̩",
+			[]string{"mrrvomrr", " ", "fmetokmungwrr"},
+			[][]SpelledIPAWord{
+				{{Syllables: []string{"mrr", "vo", "mrr"}, Stress: 2, SecondaryStress: []int{0}}}, nil,
+				{{Syllables: []string{"fme", "tok", "mung", "wrr"}, Stress: 0, SecondaryStress: []int{2}}},
+			},
+			nil,
+		},
+		{
+			"ˈʃo tʃa baw",
+			[]string{"sho", " ", "cha", " ", "baw"},
+			[][]SpelledIPAWord{
+				{{Syllables: []string{"sho"}, Stress: 0}}, nil,
+				{{Syllables: []string{"cha"}, Stress: 0}}, nil,
+				{{Syllables: []string{"baw"}, Stress: 0}},
+			},
+			nil,
+		},
+		{
+			"ˈsköxaw",
+			[]string{"skaw"},
+			[][]SpelledIPAWord{
+				{{Syllables: []string{"skaw"}, Stress: 0}},
+			},
+			[]UnknownIPASymbol{{Symbol: "ö", Position: 4}, {Symbol: "x", Position: 6}},
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			res, err := SpellIPA(row.input)
+			if row.unknown != nil {
+				assert.Equal(t, &UnknownIPASymbolsError{Symbols: row.unknown}, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			raw := make([]string, 0, len(res))
+			words := make([][]SpelledIPAWord, 0, len(res))
+			for _, part := range res {
+				raw = append(raw, part.Raw)
+				words = append(words, part.Alternatives)
+				assert.Equal(t, part.Alternatives != nil, part.IsWord)
+			}
+
+			assert.Equal(t, row.raw, raw)
+			assert.Equal(t, row.words, words)
+		})
+	}
+}
+
+func TestUnknownIPASymbolsError_Error(t *testing.T) {
+	err := &UnknownIPASymbolsError{Symbols: []UnknownIPASymbol{{Symbol: "ö", Position: 4}, {Symbol: "x", Position: 6}}}
+	assert.Equal(t, `unknown IPA symbols: "ö" at 4, "x" at 6`, err.Error())
+}
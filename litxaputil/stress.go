@@ -0,0 +1,32 @@
+package litxaputil
+
+import "slices"
+
+// SecondaryStressAwayFrom sorts the secondary stresses and removes any that are on or next to the primary stress,
+// or next to another secondary stress before it. It returns nil if there are none left.
+func SecondaryStressAwayFrom(secondaryStress []int, stress int) []int {
+	if len(secondaryStress) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(secondaryStress)
+	slices.Sort(sorted)
+
+	res := sorted[:0]
+	for _, index := range sorted {
+		if index < 0 || (stress >= 0 && index >= stress-1 && index <= stress+1) {
+			continue
+		}
+		if len(res) > 0 && index <= res[len(res)-1]+1 {
+			continue
+		}
+
+		res = append(res, index)
+	}
+
+	if len(res) == 0 {
+		return nil
+	}
+
+	return res
+}
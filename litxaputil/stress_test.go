@@ -0,0 +1,32 @@
+package litxaputil
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecondaryStressAwayFrom(t *testing.T) {
+	table := []struct {
+		secondaryStress []int
+		stress          int
+		expected        []int
+	}{
+		{nil, 0, nil},
+		{[]int{0}, 2, []int{0}},
+		{[]int{0}, 1, nil},
+		{[]int{0}, 0, nil},
+		{[]int{3}, 0, []int{3}},
+		{[]int{2, 0}, 4, []int{0, 2}},
+		{[]int{0, 1}, 4, []int{0}},
+		{[]int{1, 1}, 4, []int{1}},
+		{[]int{0, 3}, -1, []int{0, 3}},
+	}
+
+	for _, row := range table {
+		t.Run(fmt.Sprint(row.secondaryStress, row.stress), func(t *testing.T) {
+			assert.Equal(t, row.expected, SecondaryStressAwayFrom(row.secondaryStress, row.stress))
+		})
+	}
+}
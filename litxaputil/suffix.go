@@ -6,7 +6,7 @@ import (
 )
 
 func suffix(ra int, s ...string) Suffix {
-	return Suffix{reanalysis: ra, syllableSplit: s}
+	return Suffix{reanalysis: ra, syllableSplit: s, stress: -1}
 }
 
 type Suffix struct {
@@ -18,6 +18,9 @@ type Suffix struct {
 	syllableSplit []string
 	// After si
 	afterSi bool
+	// stress is the index in syllableSplit that keeps its stress as a secondary stress, which
+	// is done for the adpositions that are also their own words. It is -1 for the rest.
+	stress int
 }
 
 func (suffix Suffix) Apply(curr []string) []string {
@@ -67,13 +70,27 @@ func (suffix Suffix) isAfterSi() Suffix {
 	return suffix
 }
 
+func (suffix Suffix) withStress(index int) Suffix {
+	suffix.stress = index
+	return suffix
+}
+
 // ApplySuffixes applies the suffixes to the syllable set. None of them change stress (yet), so the stress index
 // shall remain the same before and after.
 func ApplySuffixes(curr []string, suffixNames []string, isVerb bool) []string {
+	curr, _ = ApplySuffixesWithStress(curr, suffixNames, isVerb)
+	return curr
+}
+
+// ApplySuffixesWithStress is ApplySuffixes, but it also returns the indices of syllables that get a secondary
+// stress from multi-syllable adpositions, like the te in fmetokyuteri.
+func ApplySuffixesWithStress(curr []string, suffixNames []string, isVerb bool) ([]string, []int) {
 	if len(suffixNames) == 0 {
-		return curr
+		return curr, nil
 	}
 
+	var secondaryStress []int
+
 	siVerb := isVerb && curr[len(curr)-1] == "si"
 	siApplied := false
 	if siVerb {
@@ -82,6 +99,9 @@ func ApplySuffixes(curr []string, suffixNames []string, isVerb bool) []string {
 
 	for _, suffixName := range suffixNames {
 		suffix := findSuffix(suffixName)
+		if suffix.stress >= 0 && suffix.reanalysis == sraNewSyllable {
+			secondaryStress = append(secondaryStress, len(curr)+suffix.stress)
+		}
 
 		if siVerb && suffixName == "tswo" {
 			curr = suffix.Apply(curr)
@@ -95,7 +115,7 @@ func ApplySuffixes(curr []string, suffixNames []string, isVerb bool) []string {
 		}
 	}
 
-	return curr
+	return curr, secondaryStress
 }
 
 func findSuffix(name string) Suffix {
@@ -103,7 +123,7 @@ func findSuffix(name string) Suffix {
 		return suffix
 	}
 
-	return Suffix{reanalysis: sraNewSyllable, syllableSplit: []string{name}}
+	return Suffix{reanalysis: sraNewSyllable, syllableSplit: []string{name}, stress: -1}
 }
 
 const (
@@ -129,24 +149,24 @@ var suffixMap = map[string]Suffix{
 	"uo":  suffix(sraStealCoda, "u", "o"),
 	"ìlä": suffix(sraStealCoda, "ì", "lä"),
 
-	"mungwrr": suffix(sraNewSyllable, "mung", "wrr"),
-	"teri":    suffix(sraNewSyllable, "te", "ri"),
-	"kxamlä":  suffix(sraNewSyllable, "kxam", "lä"),
-	"mìkam":   suffix(sraNewSyllable, "mì", "kam"),
-	"nemfa":   suffix(sraNewSyllable, "nem", "fa"),
-	"takip":   suffix(sraNewSyllable, "ta", "kip"),
-	"luke":    suffix(sraNewSyllable, "lu", "ke"),
-	"tafkip":  suffix(sraNewSyllable, "ta", "fkip"),
-	"pxisre":  suffix(sraNewSyllable, "pxi", "sre"),
-	"pximaw":  suffix(sraNewSyllable, "pxi", "maw"),
-	"rofa":    suffix(sraNewSyllable, "ro", "fa"),
-	"lisre":   suffix(sraNewSyllable, "li", "sre"),
-	"nuä":     suffix(sraNewSyllable, "nu", "ä"),
-	"talun":   suffix(sraNewSyllable, "ta", "lun"),
-	"yoa":     suffix(sraNewSyllable, "yo", "a"),
-	"krrka":   suffix(sraNewSyllable, "krr", "ka"),
-	"ftumfa":  suffix(sraNewSyllable, "ftum", "fa"),
-	"ftuopa":  suffix(sraNewSyllable, "ftu", "o", "pa"),
+	"mungwrr": suffix(sraNewSyllable, "mung", "wrr").withStress(0),
+	"teri":    suffix(sraNewSyllable, "te", "ri").withStress(0),
+	"kxamlä":  suffix(sraNewSyllable, "kxam", "lä").withStress(0),
+	"mìkam":   suffix(sraNewSyllable, "mì", "kam").withStress(1),
+	"nemfa":   suffix(sraNewSyllable, "nem", "fa").withStress(0),
+	"takip":   suffix(sraNewSyllable, "ta", "kip").withStress(1),
+	"luke":    suffix(sraNewSyllable, "lu", "ke").withStress(0),
+	"tafkip":  suffix(sraNewSyllable, "ta", "fkip").withStress(1),
+	"pxisre":  suffix(sraNewSyllable, "pxi", "sre").withStress(0),
+	"pximaw":  suffix(sraNewSyllable, "pxi", "maw").withStress(0),
+	"rofa":    suffix(sraNewSyllable, "ro", "fa").withStress(0),
+	"lisre":   suffix(sraNewSyllable, "li", "sre").withStress(0),
+	"nuä":     suffix(sraNewSyllable, "nu", "ä").withStress(0),
+	"talun":   suffix(sraNewSyllable, "ta", "lun").withStress(1),
+	"yoa":     suffix(sraNewSyllable, "yo", "a").withStress(0),
+	"krrka":   suffix(sraNewSyllable, "krr", "ka").withStress(0),
+	"ftumfa":  suffix(sraNewSyllable, "ftum", "fa").withStress(0),
+	"ftuopa":  suffix(sraNewSyllable, "ftu", "o", "pa").withStress(0),
 
 	"a": suffix(sraStealCoda, "a").isAfterSi(),
 	"o": suffix(sraStealCoda, "o"),
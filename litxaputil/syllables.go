@@ -4,6 +4,7 @@ import (
 	"slices"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 var preOnsets = []string{"f", "ts", "s"}
@@ -22,6 +23,37 @@ type Syllable struct {
 	NewWord   bool
 }
 
+func (s Syllable) String() string {
+	return s.PreOnset + s.Onset + s.Irregular + s.Body + s.Coda
+}
+
+// ParseSyllable splits a single syllable into its parts. Unlike SplitSyllables, it keeps the casing of the input.
+// The pronoun syllables that SplitSyllables would split in two, like oel, are parsed with the o as the onset. It
+// returns false if s is not exactly one syllable.
+func ParseSyllable(s string) (Syllable, bool) {
+	lower := strings.ToLower(s)
+	if edgeCase, ok := syllableEdgeCases[lower]; ok {
+		lower = edgeCase
+	}
+
+	split := SplitSyllables(lower)
+	if len(split) != 1 || split[0].String() != lower {
+		return Syllable{}, false
+	}
+
+	// strings.ToLower changes one rune at a time, so the parts can be taken from s by rune counts.
+	syllable := split[0]
+	runes := []rune(s)
+	pos := 0
+	for _, part := range []*string{&syllable.PreOnset, &syllable.Onset, &syllable.Irregular, &syllable.Body, &syllable.Coda} {
+		end := pos + utf8.RuneCountInString(*part)
+		*part = string(runes[pos:end])
+		pos = end
+	}
+
+	return syllable, true
+}
+
 // SplitSyllables uses predictable reanalysis rules to split a na'vi word into syllables. It will handle some
 // irregular words (including: tlalim, mangkwan, kreytu'um) but will log their irregularities.
 func SplitSyllables(s string) Syllables {
@@ -59,3 +59,38 @@ func TestParseSyllables(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSyllable(t *testing.T) {
+	table := []struct {
+		Input    string
+		Expected Syllable
+		OK       bool
+	}{
+		{"tok", Syllable{Onset: "t", Body: "o", Coda: "k"}, true},
+		{"Fmi", Syllable{PreOnset: "F", Onset: "m", Body: "i"}, true},
+		{"TSKXET", Syllable{PreOnset: "TS", Onset: "KX", Body: "E", Coda: "T"}, true},
+		{"Äng", Syllable{Body: "Ä", Coda: "ng"}, true},
+		{"Tìng", Syllable{Onset: "T", Body: "ì", Coda: "ng"}, true},
+		{"kreyt", Syllable{Onset: "k", Irregular: "r", Body: "ey", Coda: "t"}, true},
+		{"fme", Syllable{PreOnset: "f", Onset: "m", Body: "e"}, true},
+		{"Oel", Syllable{Onset: "O", Body: "e", Coda: "l"}, true},
+		{"oeng", Syllable{Onset: "o", Body: "e", Coda: "ng"}, true},
+		{"\u212Aa", Syllable{Onset: "\u212A", Body: "a"}, true},
+		{"oa", Syllable{}, false},
+		{"fmetok", Syllable{}, false},
+		{" ", Syllable{}, false},
+		{"", Syllable{}, false},
+		{"rr", Syllable{}, false},
+	}
+
+	for _, row := range table {
+		t.Run(row.Input, func(t *testing.T) {
+			res, ok := ParseSyllable(row.Input)
+			assert.Equal(t, row.OK, ok)
+			assert.Equal(t, row.Expected, res)
+			if ok {
+				assert.Equal(t, row.Input, res.String())
+			}
+		})
+	}
+}
@@ -2,10 +2,15 @@ package litxap
 
 import (
 	"fmt"
-	"github.com/gissleh/litxap/litxaputil"
+	"slices"
+	"strconv"
 	"strings"
+
+	"github.com/gissleh/litxap/litxaputil"
 )
 
+// NumberDictionary looks up the octal numbers from kew (0) to litxaputil.MaxNumber, including their ordinals and the
+// attributive a. Na'vi has no words for negative numbers or fractions, so they are not found.
 type NumberDictionary struct{}
 
 func (n *NumberDictionary) LookupEntries(word string) ([]Entry, error) {
@@ -14,7 +19,11 @@ func (n *NumberDictionary) LookupEntries(word string) ([]Entry, error) {
 		return nil, ErrEntryNotFound
 	}
 
-	syllables, stress, _ := res.GenerateSyllables(false)
+	syllables, stress, secondaryStress, ok := litxaputil.GenerateNumberWithSecondaryStress(res.Value, res.Ordinal)
+	if !ok {
+		return nil, ErrEntryNotFound
+	}
+
 	numberKind := "Number"
 	if res.Ordinal {
 		numberKind = "Ordinal number"
@@ -36,11 +45,137 @@ func (n *NumberDictionary) LookupEntries(word string) ([]Entry, error) {
 	}
 
 	return []Entry{{
-		Word:        strings.Join(syllables, ""),
-		Translation: translation,
-		Syllables:   syllables,
-		Stress:      stress,
-		Prefixes:    prefixes,
-		Suffixes:    suffixes,
+		Word:            strings.Join(syllables, ""),
+		Translation:     translation,
+		PartOfSpeech:    litxaputil.PoSNumber,
+		Syllables:       syllables,
+		Stress:          stress,
+		SecondaryStress: secondaryStress,
+		Prefixes:        prefixes,
+		Suffixes:        suffixes,
 	}}, nil
 }
+
+// ConvertNumbers makes the numbers written with digits into words, so they are read as Na'vi. They're decimal unless
+// they start with °, which makes them octal like "°12". A number ending with ° or -ve, like "3°" or "°12-ve", is
+// ordinal. The new parts keep the digits in Raw and have IsNumber set, and Line.Run leaves them as they are. Numbers
+// that are too large for litxaputil.GenerateNumber are left as text, and so are grouped or decimal numbers like
+// "3,000" and "1.5".
+func (line Line) ConvertNumbers() Line {
+	var res Line
+	for i := 0; i < len(line); i++ {
+		part := line[i]
+		if part.IsWord || !strings.ContainsAny(part.Raw, numberDigits) {
+			res = append(res, part)
+			continue
+		}
+
+		if res == nil {
+			res = append(make(Line, 0, len(line)+2), line[:i]...)
+		}
+
+		rest := part.Raw
+		for rest != "" {
+			start, end, value, ordinal := findNumber(rest)
+			if start == -1 {
+				res = append(res, LinePart{Raw: rest})
+				break
+			}
+
+			raw := rest[start:end]
+			suffixed := end == len(rest) && !ordinal && i+1 < len(line) && strings.EqualFold(line[i+1].Raw, "-ve")
+
+			match, ok := numberMatch(value, ordinal || suffixed)
+			if !ok {
+				// The -ve is left for the next part.
+				res = append(res, LinePart{Raw: rest[:end]})
+				rest = rest[end:]
+				continue
+			}
+			if suffixed {
+				raw += line[i+1].Raw
+				i += 1
+			}
+
+			if start > 0 {
+				res = append(res, LinePart{Raw: rest[:start]})
+			}
+			res = append(res, LinePart{Raw: raw, IsWord: true, IsNumber: true, Matches: []LinePartMatch{match}})
+			rest = rest[end:]
+		}
+	}
+
+	if res == nil {
+		return line
+	}
+
+	return res
+}
+
+// findNumber finds the first number in s, with the ° marks around it. The start is -1 if there is none.
+func findNumber(s string) (start, end, value int, ordinal bool) {
+	start = strings.IndexAny(s, numberDigits)
+	if start == -1 {
+		return -1, -1, 0, false
+	}
+
+	end = start
+	grouped := false
+	for end < len(s) {
+		if strings.IndexByte(numberDigits, s[end]) != -1 {
+			end += 1
+		} else if strings.IndexByte(numberSeparators, s[end]) != -1 && end+1 < len(s) &&
+			strings.IndexByte(numberDigits, s[end+1]) != -1 {
+			grouped = true
+			end += 1
+		} else {
+			break
+		}
+	}
+	if grouped {
+		return start, end, -1, false
+	}
+	digits := s[start:end]
+
+	base := 10
+	if strings.HasSuffix(s[:start], "°") {
+		base = 8
+		start -= len("°")
+	}
+	if strings.HasPrefix(s[end:], "°") {
+		ordinal = true
+		end += len("°")
+	}
+
+	parsed, err := strconv.ParseInt(digits, base, 64)
+	if err != nil || parsed > litxaputil.MaxNumber {
+		// It's still returned so the caller can skip past it.
+		parsed = -1
+	}
+
+	return start, end, int(parsed), ordinal
+}
+
+// numberMatch makes the match for a number, with the entry NumberDictionary would give.
+func numberMatch(value int, ordinal bool) (LinePartMatch, bool) {
+	syllables, _, ok := litxaputil.GenerateNumber(value, ordinal)
+	if !ok {
+		return LinePartMatch{}, false
+	}
+
+	entries, err := (&NumberDictionary{}).LookupEntries(strings.Join(syllables, ""))
+	if err != nil {
+		return LinePartMatch{}, false
+	}
+
+	entry := entries[0]
+	return LinePartMatch{
+		Syllables:       slices.Clone(entry.Syllables),
+		Stress:          entry.Stress,
+		SecondaryStress: entry.SecondaryStress,
+		Entry:           entry,
+	}, true
+}
+
+const numberDigits = "0123456789"
+const numberSeparators = ",.:"
@@ -11,17 +11,25 @@ func TestNumberDictionary_LookupEntries(t *testing.T) {
 		Lookup  string
 		Results string
 	}{
-		{"azave", "za.ve: a-: Ordinal number °100 (64)"},
-		{"amrr", "mrr: a-: Number 5"},
-		{"mezavea", "me.za.ve: -a: Ordinal number °200 (128)"},
-		{"Tsìvol", "tsì.vol: : Number °40 (32)"},
-		{"mevozam", "me.vo.zam: : Number °2000 (1024)"},
-		{"mevozave", "me.vo.za.ve: : Ordinal number °2000 (1024)"},
-		{"mrrvomrr", "mrr.vo.*mrr: : Number °55 (45)"},
-		{"mrrvozam", "mrr.vo.zam: : Number °5000 (2560)"},
-		{"mrrvozamvol", "mrr.vo.zam.vol: : Number °5010 (2568)"},
-		{"mrrvozammevol", "mrr.vo.zam.me.vol: : Number °5020 (2576)"},
-		{"mezamvolaw", "me.zam.vo.*law: : Number °211 (137)"},
+		{"azave", "za.ve: a- $pos:num.: Ordinal number °100 (64)"},
+		{"amrr", "mrr: a- $pos:num.: Number 5"},
+		{"mezavea", "me.za.ve: -a $pos:num.: Ordinal number °200 (128)"},
+		{"Tsìvol", "tsì.vol: $pos:num.: Number °40 (32)"},
+		{"mevozam", "me.vo.zam: $pos:num.: Number °2000 (1024)"},
+		{"mevozave", "me.vo.za.ve: $pos:num.: Ordinal number °2000 (1024)"},
+		{"mrrvomrr", "ˌmrr.vo.*mrr: $pos:num.: Number °55 (45)"},
+		{"mrrvozam", "mrr.vo.zam: $pos:num.: Number °5000 (2560)"},
+		{"mrrvozamvol", "mrr.vo.zam.ˌvol: $pos:num.: Number °5010 (2568)"},
+		{"mrrvozammevol", "mrr.vo.zam.ˌme.vol: $pos:num.: Number °5020 (2576)"},
+		{"mezamvolaw", "ˌme.zam.vo.*law: $pos:num.: Number °211 (137)"},
+		{"kew", "kew: $pos:num.: Number 0"},
+		{"Kewa", "kew: -a $pos:num.: Number 0"},
+		{"vozazam", "vo.za.zam: $pos:num.: Number °100000 (32768)"},
+		{"pxevozazamvol", "pxe.vo.za.zam.ˌvol: $pos:num.: Number °300010 (98312)"},
+		{"kewve", ""},
+		{"mezamit", "me.zam: -it $pos:num.: Number °200 (128)"},
+		{"Pukapru", "pu.kap: -ru $pos:num.: Number 6"},
+		{"mezamt", ""},
 		{"mrrvomrrr", ""},
 		{"amrra", ""},
 	}
@@ -42,3 +50,76 @@ func TestNumberDictionary_LookupEntries(t *testing.T) {
 		})
 	}
 }
+
+func TestRunLine_NumberSuffixes(t *testing.T) {
+	line, err := RunLine("Mezamit, pukapru sì vozamteri!", &NumberDictionary{})
+	assert.NoError(t, err)
+	assert.Equal(t, "[S]{Me}zamit[/S], [S]{pu}kapru[/S] [NM]sì[/NM] [S]{vo}zamteri[/S]!", line.Format(&dummyLineFormatter{}, nil))
+	assert.Equal(t, []string{"Me", "za", "mit"}, line[0].Matches[0].Syllables)
+	assert.Equal(t, []string{"vo", "zam", "te", "ri"}, line[6].Matches[0].Syllables)
+	assert.Equal(t, []int{2}, line[6].Matches[0].SecondaryStress)
+}
+
+func TestLine_ConvertNumbers(t *testing.T) {
+	table := []struct {
+		input    string
+		output   string
+		original string
+	}{
+		{
+			input:    "Fìtsengit 3 tsmuk lu.",
+			output:   "[NM]Fìtsengit[/NM] [S]pxey[/S] [NM]tsmuk[/NM] [NM]lu[/NM].",
+			original: "[NM]Fìtsengit[/NM] [S]3[/S] [NM]tsmuk[/NM] [NM]lu[/NM].",
+		},
+		{
+			input:    "°12, 10 sì 45!",
+			output:   "[S]vo{mun}[/S], [S]vo{mun}[/S] [NM]sì[/NM] [S]mrrvo{mrr}[/S]!",
+			original: "[S]°12[/S], [S]10[/S] [NM]sì[/NM] [S]45[/S]!",
+		},
+		{
+			input:    "°3° 2-ve °100-ve",
+			output:   "[S]{pxey}ve[/S] [S]{mu}ve[/S] [S]{za}ve[/S]",
+			original: "[S]°3°[/S] [S]2-ve[/S] [S]°100-ve[/S]",
+		},
+		{
+			input:    "0 °0",
+			output:   "[S]kew[/S] [S]kew[/S]",
+			original: "[S]0[/S] [S]°0[/S]",
+		},
+		{
+			input:    "°19 1000000 0°.",
+			output:   "°19 1000000 0°.",
+			original: "°19 1000000 0°.",
+		},
+		{
+			input:    "0-ve °9-ve 1000000-ve",
+			output:   "0[NM]-ve[/NM] °9[NM]-ve[/NM] 1000000[NM]-ve[/NM]",
+			original: "0[NM]-ve[/NM] °9[NM]-ve[/NM] 1000000[NM]-ve[/NM]",
+		},
+		{
+			input:    "3,000 1.5 12:30, 7.",
+			output:   "3,000 1.5 12:30, [S]{ki}nä[/S].",
+			original: "3,000 1.5 12:30, [S]7[/S].",
+		},
+		{
+			input:    "Kaltxì!",
+			output:   "[NM]Kaltxì[/NM]!",
+			original: "[NM]Kaltxì[/NM]!",
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			line, err := ParseLine(row.input).ConvertNumbers().Run(&NumberDictionary{})
+			assert.NoError(t, err)
+			assert.Equal(t, row.output, line.Format(&dummyLineFormatter{}, nil))
+			assert.Equal(t, row.original, line.FormatWithMode(&dummyLineFormatter{}, nil, FormatOriginal))
+
+			raw := ""
+			for _, part := range line {
+				raw += part.Raw
+			}
+			assert.Equal(t, row.input, raw)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package litxap
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gissleh/litxap/litxaputil"
+)
+
+// PhonemeOptions decides the alphabet and the durations for Line.Phonemes. The zero value is usable.
+type PhonemeOptions struct {
+	Alphabet litxaputil.PhonemeAlphabet
+	// Fallback transcribes words without matches, like IPAOptions.Fallback does.
+	Fallback bool
+	// VowelDuration is the duration of vowels, diphthongs and pseudovowels in milliseconds. It defaults to 120.
+	VowelDuration int
+	// ConsonantDuration is the duration of consonants in milliseconds. It defaults to 70.
+	ConsonantDuration int
+	// PauseDuration is the duration of the pause at punctuation in milliseconds. It defaults to 250.
+	PauseDuration int
+	// StressFactor lengthens the stressed syllables. It defaults to 1.5.
+	StressFactor float64
+	// SecondaryStressFactor lengthens the syllables with secondary stress. It defaults to 1.25.
+	SecondaryStressFactor float64
+}
+
+// Phoneme is one sound or pause in a phoneme stream.
+type Phoneme struct {
+	// Symbol is the phoneme in the chosen alphabet, or PhonemePause for a pause.
+	Symbol string
+	// Duration is in milliseconds.
+	Duration int
+	// Stress is 2 for stressed syllables, 1 for secondary stress and 0 for the rest.
+	Stress int
+	// PartIndex is the index of the LinePart the phoneme is from.
+	PartIndex int
+	// SyllableIndex is the index of the syllable in the selected match, or -1 for pauses.
+	SyllableIndex int
+}
+
+// PhonemePause is the symbol for silence, which is the same as MBROLA uses.
+const PhonemePause = "_"
+
+// Phonemes is a phoneme stream.
+type Phonemes []Phoneme
+
+// Phonemes makes a phoneme stream for the line, with a pause for each punctuation. Like IPA, it fails on
+// the first word that cannot be transcribed.
+func (line Line) Phonemes(selections map[int]int, opts PhonemeOptions) (Phonemes, error) {
+	if opts.VowelDuration == 0 {
+		opts.VowelDuration = 120
+	}
+	if opts.ConsonantDuration == 0 {
+		opts.ConsonantDuration = 70
+	}
+	if opts.PauseDuration == 0 {
+		opts.PauseDuration = 250
+	}
+	if opts.StressFactor == 0 {
+		opts.StressFactor = 1.5
+	}
+	if opts.SecondaryStressFactor == 0 {
+		opts.SecondaryStressFactor = 1.25
+	}
+
+	res := make(Phonemes, 0, len(line)*4)
+	for i, part := range line {
+		if !part.IsWord {
+			if strings.Trim(part.Raw, "  \t\r\n-") != "" {
+				res = append(res, Phoneme{Symbol: PhonemePause, Duration: opts.PauseDuration, PartIndex: i, SyllableIndex: -1})
+			}
+
+			continue
+		}
+
+		word, err := line.ipaWord(i, selections, IPAOptions{Fallback: opts.Fallback})
+		if err != nil {
+			return nil, err
+		}
+
+		for j, syllable := range word.syllables {
+			if strings.TrimSpace(syllable) == "" {
+				continue
+			}
+
+			symbols, err := litxaputil.SyllablePhonemes(syllable, opts.Alphabet)
+			if err != nil {
+				return nil, err
+			}
+
+			stress, factor := 0, 1.0
+			if j == word.stress {
+				stress, factor = 2, opts.StressFactor
+			} else if slices.Contains(word.secondaryStress, j) {
+				stress, factor = 1, opts.SecondaryStressFactor
+			}
+
+			for _, symbol := range symbols {
+				duration := opts.ConsonantDuration
+				if litxaputil.IsVowelPhoneme(symbol, opts.Alphabet) {
+					duration = opts.VowelDuration
+				}
+
+				res = append(res, Phoneme{
+					Symbol:        symbol,
+					Duration:      int(float64(duration)*factor + 0.5),
+					Stress:        stress,
+					PartIndex:     i,
+					SyllableIndex: j,
+				})
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// Duration is the total duration in milliseconds.
+func (phonemes Phonemes) Duration() int {
+	total := 0
+	for _, phoneme := range phonemes {
+		total += phoneme.Duration
+	}
+
+	return total
+}
+
+// WritePhoTo writes the phonemes as an MBROLA .pho file with one phoneme and its duration per line, and a pause at
+// the start and end.
+func (phonemes Phonemes) WritePhoTo(w io.StringWriter) error {
+	lines := make([]string, 0, len(phonemes)+2)
+	if len(phonemes) == 0 || phonemes[0].Symbol != PhonemePause {
+		lines = append(lines, PhonemePause+" 100")
+	}
+	for _, phoneme := range phonemes {
+		lines = append(lines, fmt.Sprintf("%s %d", phoneme.Symbol, phoneme.Duration))
+	}
+	if len(phonemes) == 0 || phonemes[len(phonemes)-1].Symbol != PhonemePause {
+		lines = append(lines, PhonemePause+" 100")
+	}
+
+	for _, line := range lines {
+		_, err := w.WriteString(line + "\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Pho returns the same as WritePhoTo as a string.
+func (phonemes Phonemes) Pho() string {
+	sb := &strings.Builder{}
+	_ = phonemes.WritePhoTo(sb)
+	return sb.String()
+}
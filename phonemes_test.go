@@ -0,0 +1,61 @@
+package litxap
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gissleh/litxap/litxaputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestLine_Phonemes(t *testing.T) {
+	table := []struct {
+		name       string
+		input      Line
+		opts       PhonemeOptions
+		selections map[int]int
+	}{
+		{"kaltxi_ma_fmetokyu", lineKaltxiMaFmetokyu, PhonemeOptions{}, nil},
+		{"oel_ngati_kameie", lineOelNgatiKameie, PhonemeOptions{Alphabet: litxaputil.PhonemesESpeak}, nil},
+		{"mrrvomrr_fmetokmungwrr", lineMrrvomrrFmetokmungwrr, PhonemeOptions{}, nil},
+		{"fikem_ila_fyao", lineFikemIlaFyao, PhonemeOptions{VowelDuration: 100, ConsonantDuration: 50, StressFactor: 2}, map[int]int{2: 1}},
+		{"vola_skeynven", lineVolaSkeynven, PhonemeOptions{Fallback: true, PauseDuration: 400}, nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			phonemes, err := row.input.Phonemes(row.selections, row.opts)
+			require.NoError(t, err)
+
+			goldenPath := filepath.Join("testdata", "phonemes", row.name+".pho")
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(goldenPath, []byte(phonemes.Pho()), 0644))
+			}
+
+			golden, err := os.ReadFile(goldenPath)
+			require.NoError(t, err)
+			assert.Equal(t, string(golden), phonemes.Pho())
+		})
+	}
+}
+
+func TestLine_Phonemes_Fail(t *testing.T) {
+	_, err := lineVolaSkeynven.Phonemes(nil, PhonemeOptions{})
+	assert.Error(t, err)
+
+	_, err = lineFmetokBad.Phonemes(nil, PhonemeOptions{})
+	assert.Error(t, err)
+}
+
+func TestPhonemes_Duration(t *testing.T) {
+	phonemes, err := lineKaltxiMaFmetokyu.Phonemes(nil, PhonemeOptions{})
+	require.NoError(t, err)
+
+	// Kal.TXÌ , ma FME.tok.yu !
+	assert.Equal(t, (70+120+70)+(105+180)+250+(70+120)+(105+105+180)+(70+120+70)+(70+120)+250, phonemes.Duration())
+}
@@ -0,0 +1,48 @@
+package litxap
+
+import (
+	"errors"
+
+	"github.com/gissleh/litxap/litxaputil"
+)
+
+// ReefDictionary lets Reef Na'vi words be looked up in a Forest dictionary. A word that isn't found is looked up
+// again with the Forest spellings from litxaputil.ForestSpellings, e.g. getse as kxetse. The entries are the Forest
+// ones, but since RunLine matches them against the word in the line, the syllables keep their Reef spelling.
+type ReefDictionary struct {
+	Dictionary Dictionary
+	// MaxSpellings is the most Forest spellings to try for each word. It defaults to 16.
+	MaxSpellings int
+}
+
+func (d *ReefDictionary) LookupEntries(word string) ([]Entry, error) {
+	entries, err := d.Dictionary.LookupEntries(word)
+	if err == nil || !errors.Is(err, ErrEntryNotFound) {
+		return entries, err
+	}
+
+	maxSpellings := d.MaxSpellings
+	if maxSpellings <= 0 {
+		maxSpellings = 16
+	}
+
+	var res []Entry
+	for _, spelling := range litxaputil.ForestSpellings(word, maxSpellings) {
+		entries, err := d.Dictionary.LookupEntries(spelling)
+		if err != nil {
+			if errors.Is(err, ErrEntryNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		res = append(res, entries...)
+	}
+
+	if len(res) == 0 {
+		return nil, ErrEntryNotFound
+	}
+
+	return res, nil
+}
@@ -0,0 +1,52 @@
+package litxap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReefDictionary_LookupEntries(t *testing.T) {
+	table := []struct {
+		name     string
+		dict     ReefDictionary
+		word     string
+		expected []Entry
+	}{
+		{"Forest", ReefDictionary{Dictionary: dummyDictionary}, "ngati", []Entry{dummyDictionary["ngati"]}},
+		{"UnstressedE", ReefDictionary{Dictionary: dummyDictionary}, "sekeynven", []Entry{dummyDictionary["säkeynven"]}},
+		{"Voiced", ReefDictionary{Dictionary: dummyDictionary}, "getse", []Entry{dummyDictionary["kxetse"]}},
+		{"Ch", ReefDictionary{Dictionary: dummyDictionary}, "chal", []Entry{dummyDictionary["tsyal"]}},
+		{"NotFound", ReefDictionary{Dictionary: dummyDictionary}, "shawm", nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			res, err := row.dict.LookupEntries(row.word)
+			if row.expected == nil {
+				assert.ErrorIs(t, err, ErrEntryNotFound)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, row.expected, res)
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := (&ReefDictionary{Dictionary: BrokenDictionary{}}).LookupEntries("getse")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrEntryNotFound)
+	})
+}
+
+func TestReefDictionary_RunLine(t *testing.T) {
+	line, err := RunLine("Sekeynven chal getse.", &ReefDictionary{Dictionary: dummyDictionary})
+	require.NoError(t, err)
+	require.Len(t, line, 6)
+	assert.Equal(t, []string{"Se", "keyn", "ven"}, line[0].Matches[0].Syllables)
+	assert.Equal(t, 2, line[0].Matches[0].Stress)
+	assert.Equal(t, []string{"chal"}, line[2].Matches[0].Syllables)
+	assert.Equal(t, []string{"ge", "tse"}, line[4].Matches[0].Syllables)
+	assert.Equal(t, 0, line[4].Matches[0].Stress)
+}
@@ -0,0 +1,162 @@
+package litxap
+
+import (
+	"io"
+	"slices"
+	"strings"
+)
+
+// SSMLOptions decides how Line.SSML writes the document. The zero value is usable.
+type SSMLOptions struct {
+	// IPA is used for the transcriptions. The SyllableDelimiter and WordSeparator are not used.
+	IPA IPAOptions
+	// Lang is put in the xml:lang attribute of the speak element, if set.
+	Lang string
+	// StressProsody, if set, writes each syllable as its own phoneme element, with a prosody element around
+	// the stressed syllable.
+	StressProsody SSMLProsody
+	// SecondaryStressProsody is like StressProsody, but for the syllables with secondary stress.
+	SecondaryStressProsody SSMLProsody
+	// ClauseBreak is the break strength for commas and the like. It defaults to "medium".
+	ClauseBreak string
+	// SentenceBreak is the break strength for the end of sentences. It defaults to "strong".
+	SentenceBreak string
+}
+
+// SSMLProsody are the attributes of a prosody element, e.g. Pitch: "+10%" or Volume: "loud".
+type SSMLProsody struct {
+	Pitch  string
+	Rate   string
+	Volume string
+}
+
+func (p SSMLProsody) isZero() bool {
+	return p == SSMLProsody{}
+}
+
+// SSML makes a speak document with a phoneme element for each word and a break element for each punctuation.
+// Words that cannot be transcribed are written as plain text.
+func (line Line) SSML(selections map[int]int, opts SSMLOptions) string {
+	sb := &strings.Builder{}
+	_ = line.WriteSSMLTo(sb, selections, opts)
+	return sb.String()
+}
+
+// WriteSSMLTo writes the same as SSML.
+func (line Line) WriteSSMLTo(w io.StringWriter, selections map[int]int, opts SSMLOptions) error {
+	if opts.ClauseBreak == "" {
+		opts.ClauseBreak = "medium"
+	}
+	if opts.SentenceBreak == "" {
+		opts.SentenceBreak = "strong"
+	}
+
+	perSyllable := !opts.StressProsody.isZero() || !opts.SecondaryStressProsody.isZero()
+
+	ipaOpts := opts.IPA
+	ipaOpts.WordSeparator = ""
+	ipaOpts.SyllableDelimiter = ""
+	if perSyllable {
+		ipaOpts.SyllableDelimiter = "."
+	}
+	ipa := line.IPAWithOptions(selections, ipaOpts)
+
+	sb := &strings.Builder{}
+	sb.WriteString("<speak")
+	if opts.Lang != "" {
+		sb.WriteString(` xml:lang="` + ssmlEscaper.Replace(opts.Lang) + `"`)
+	}
+	sb.WriteString(">")
+
+	for i, part := range line {
+		if !part.IsWord {
+			trimmed := strings.Trim(part.Raw, "  \t\r\n-")
+			switch {
+			case trimmed == "":
+			case strings.ContainsAny(trimmed, ".!?…"):
+				sb.WriteString(`<break strength="` + ssmlEscaper.Replace(opts.SentenceBreak) + `"/>`)
+			default:
+				sb.WriteString(`<break strength="` + ssmlEscaper.Replace(opts.ClauseBreak) + `"/>`)
+			}
+			if strings.ContainsAny(part.Raw, "  \t\r\n") {
+				sb.WriteString(" ")
+			}
+
+			continue
+		}
+
+		if ipa[i].Err != nil {
+			sb.WriteString(ssmlEscaper.Replace(part.Raw))
+			continue
+		}
+
+		if perSyllable {
+			if word, err := line.ipaWord(i, selections, ipaOpts); err == nil && writeSSMLSyllables(sb, word, ipa[i].IPA, opts) {
+				continue
+			}
+		}
+
+		writeSSMLPhoneme(sb, strings.ReplaceAll(ipa[i].IPA, ".", ""), part.Raw)
+	}
+
+	sb.WriteString("</speak>")
+
+	_, err := w.WriteString(sb.String())
+	return err
+}
+
+// writeSSMLSyllables writes a phoneme element per syllable, and returns false if the IPA didn't line up with
+// the syllables.
+func writeSSMLSyllables(sb *strings.Builder, word ipaWord, ipa string, opts SSMLOptions) bool {
+	pieces := strings.FieldsFunc(ipa, func(r rune) bool { return r == '.' || r == ' ' })
+
+	indices := make([]int, 0, len(word.syllables))
+	for j, syllable := range word.syllables {
+		if strings.TrimSpace(syllable) != "" {
+			indices = append(indices, j)
+		}
+	}
+	if len(indices) != len(pieces) {
+		return false
+	}
+
+	for k, j := range indices {
+		if k > 0 && j > indices[k-1]+1 {
+			sb.WriteString(" ")
+		}
+
+		prosody := SSMLProsody{}
+		if j == word.stress {
+			prosody = opts.StressProsody
+		} else if slices.Contains(word.secondaryStress, j) {
+			prosody = opts.SecondaryStressProsody
+		}
+
+		if prosody.isZero() {
+			writeSSMLPhoneme(sb, pieces[k], word.text[j])
+			continue
+		}
+
+		sb.WriteString("<prosody")
+		for _, attr := range [][2]string{{"pitch", prosody.Pitch}, {"rate", prosody.Rate}, {"volume", prosody.Volume}} {
+			if attr[1] != "" {
+				sb.WriteString(" " + attr[0] + `="` + ssmlEscaper.Replace(attr[1]) + `"`)
+			}
+		}
+		sb.WriteString(">")
+		writeSSMLPhoneme(sb, pieces[k], word.text[j])
+		sb.WriteString("</prosody>")
+	}
+
+	return true
+}
+
+func writeSSMLPhoneme(sb *strings.Builder, ipa, text string) {
+	sb.WriteString(`<phoneme alphabet="ipa" ph="`)
+	sb.WriteString(ssmlEscaper.Replace(ipa))
+	sb.WriteString(`">`)
+	sb.WriteString(ssmlEscaper.Replace(text))
+	sb.WriteString("</phoneme>")
+}
+
+var ssmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;")
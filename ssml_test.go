@@ -0,0 +1,92 @@
+package litxap
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLine_SSML(t *testing.T) {
+	table := []struct {
+		name   string
+		input  Line
+		opts   SSMLOptions
+		output string
+	}{
+		{
+			"Default", lineKaltxiMaFmetokyu, SSMLOptions{},
+			`<speak><phoneme alphabet="ipa" ph="kalˈtʼɪ">Kaltxì</phoneme><break strength="medium"/> <phoneme alphabet="ipa" ph="ma">ma</phoneme> <phoneme alphabet="ipa" ph="ˈfmɛtok̚ju">fmetokyu</phoneme><break strength="strong"/></speak>`,
+		},
+		{
+			"Options", lineKaltxiMaFmetokyu, SSMLOptions{Lang: `x-"navi"`, IPA: IPAOptions{Transcription: IPAPhonemic}, ClauseBreak: "weak", SentenceBreak: "x-strong"},
+			`<speak xml:lang="x-&quot;navi&quot;"><phoneme alphabet="ipa" ph="kalˈtʼɪ">Kaltxì</phoneme><break strength="weak"/> <phoneme alphabet="ipa" ph="ma">ma</phoneme> <phoneme alphabet="ipa" ph="ˈfmɛtokju">fmetokyu</phoneme><break strength="x-strong"/></speak>`,
+		},
+		{
+			"StressProsody", lineMrrvomrrFmetokmungwrr, SSMLOptions{StressProsody: SSMLProsody{Pitch: "+10%", Rate: "slow"}, SecondaryStressProsody: SSMLProsody{Volume: "loud"}},
+			`<speak><prosody volume="loud"><phoneme alphabet="ipa" ph="ˌmr̩">Mrr</phoneme></prosody><phoneme alphabet="ipa" ph="vo">vo</phoneme><prosody pitch="+10%" rate="slow"><phoneme alphabet="ipa" ph="ˈmr̩">mrr</phoneme></prosody> <prosody pitch="+10%" rate="slow"><phoneme alphabet="ipa" ph="ˈfmɛ">fme</phoneme></prosody><phoneme alphabet="ipa" ph="tok̚">tok</phoneme><prosody volume="loud"><phoneme alphabet="ipa" ph="ˌmuŋ">mung</phoneme></prosody><phoneme alphabet="ipa" ph="wr̩">wrr</phoneme><break strength="strong"/></speak>`,
+		},
+		{
+			"NoMatches", lineVolaSkeynven, SSMLOptions{},
+			`<speak><phoneme alphabet="ipa" ph="ˈvola">Vola</phoneme> skeynven<break strength="strong"/></speak>`,
+		},
+		{
+			"Fallback", lineVolaSkeynven, SSMLOptions{IPA: IPAOptions{Fallback: true}},
+			`<speak><phoneme alphabet="ipa" ph="ˈvola">Vola</phoneme> <phoneme alphabet="ipa" ph="skɛjnvɛn">skeynven</phoneme><break strength="strong"/></speak>`,
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			assert.Equal(t, row.output, row.input.SSML(nil, row.opts))
+		})
+	}
+}
+
+func TestLine_SSML_XMLRoundTrip(t *testing.T) {
+	for _, line := range []Line{lineKaltxiMaFmetokyu, lineTingPxawFmetokUlteRey, lineMrrvomrrFmetokmungwrr} {
+		for _, opts := range []SSMLOptions{{}, {Lang: "art-x-navi", StressProsody: SSMLProsody{Pitch: "high"}}} {
+			ssml := line.SSML(nil, opts)
+
+			ipa := &strings.Builder{}
+			text := &strings.Builder{}
+			decoder := xml.NewDecoder(strings.NewReader(ssml))
+			for {
+				token, err := decoder.Token()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				switch token := token.(type) {
+				case xml.StartElement:
+					if token.Name.Local == "phoneme" {
+						for _, attr := range token.Attr {
+							if attr.Name.Local == "ph" {
+								ipa.WriteString(attr.Value)
+							}
+						}
+					}
+				case xml.CharData:
+					text.Write(token)
+				}
+			}
+
+			expectedIPA := line.IPAWithOptions(nil, IPAOptions{WordSeparator: " "}).String()
+			assert.Equal(t, strings.ReplaceAll(expectedIPA, " ", ""), ipa.String())
+
+			expectedText := &strings.Builder{}
+			for _, part := range line {
+				if part.IsWord {
+					expectedText.WriteString(part.Raw)
+				}
+			}
+			assert.Equal(t, expectedText.String(), strings.ReplaceAll(text.String(), " ", ""))
+		}
+	}
+}
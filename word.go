@@ -1,10 +1,61 @@
 package litxap
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/gissleh/litxap/litxaputil"
 )
 
 func RunWord(word string, entry Entry) ([]string, int) {
-	syllables, stress, root := entry.GenerateSyllables()
-	return litxaputil.MatchSyllables(word, syllables, root, stress)
+	syllables, stress, _ := RunWordWithSecondaryStress(word, entry)
+	return syllables, stress
+}
+
+// RunWordWithSecondaryStress is RunWord, but it also gives the indices of the syllables with secondary stress. A secondary
+// stress is dropped if the word's syllables were changed in a way that loses track of it. In multi-word entries like
+// uvan si, the words with one syllable are also given a secondary stress unless they have the stress.
+func RunWordWithSecondaryStress(word string, entry Entry) ([]string, int, []int) {
+	syllables, stress, root, secondaryStress := entry.GenerateSyllablesWithSecondaryStress()
+	res, resStress := litxaputil.MatchSyllables(word, syllables, root, stress)
+	if res == nil {
+		return nil, resStress, nil
+	}
+
+	var resSecondaryStress []int
+	for _, index := range secondaryStress {
+		matched, matchedIndex := litxaputil.MatchSyllables(word, syllables, root, index)
+		if matchedIndex >= 0 && slices.Equal(matched, res) {
+			resSecondaryStress = append(resSecondaryStress, matchedIndex)
+		}
+	}
+
+	if wordStress := multiWordSecondaryStress(res, resStress); wordStress != nil {
+		resSecondaryStress = append(resSecondaryStress, wordStress...)
+		slices.Sort(resSecondaryStress)
+		resSecondaryStress = slices.Compact(resSecondaryStress)
+	}
+
+	return res, resStress, resSecondaryStress
+}
+
+// multiWordSecondaryStress finds the words with one syllable in a multi-word match, where the words are split by
+// syllables with only spaces in them.
+func multiWordSecondaryStress(syllables []string, stress int) []int {
+	var res []int
+
+	start := 0
+	for i := 0; i <= len(syllables); i++ {
+		if i < len(syllables) && strings.TrimSpace(syllables[i]) != "" {
+			continue
+		}
+
+		if i-start == 1 && start != stress && i-start < len(syllables) {
+			res = append(res, start)
+		}
+
+		start = i + 1
+	}
+
+	return res
 }
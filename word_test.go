@@ -255,3 +255,48 @@ func TestRunWord(t *testing.T) {
 		})
 	}
 }
+
+func TestRunWordWithSecondaryStress(t *testing.T) {
+	table := []struct {
+		Raw                string
+		Entry              string
+		Res                string
+		ResStress          int
+		ResSecondaryStress []int
+	}{
+		{
+			Raw: "Fmetok", Entry: "fme.tok",
+			Res: "Fme.tok", ResStress: 0, ResSecondaryStress: nil,
+		},
+		{
+			Raw: "mrrvomrrìl", Entry: "ˌmrr.vo.*mrr: -ìl",
+			Res: "mrr.vo.mrr.ìl", ResStress: 2, ResSecondaryStress: []int{0},
+		},
+		{
+			Raw: "ayfmetokteri", Entry: "fme.tok: ay- -teri",
+			Res: "ay.fme.tok.te.ri", ResStress: 1, ResSecondaryStress: []int{3},
+		},
+		{
+			Raw: "uvan si", Entry: "u.*van.s··i",
+			Res: "u.van. .si", ResStress: 1, ResSecondaryStress: []int{3},
+		},
+		{
+			Raw: "Tsaheyl si", Entry: "tsa.heyl.s··i: no_stress",
+			Res: "Tsa.heyl. .si", ResStress: -1, ResSecondaryStress: []int{3},
+		},
+		{
+			Raw: "tskxekeng sìsyi", Entry: "tskxe.keng.s··i: <ìsy>",
+			Res: "tskxe.keng. .sì.syi", ResStress: 0, ResSecondaryStress: nil,
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.Raw, func(t *testing.T) {
+			res, resStress, resSecondaryStress := RunWordWithSecondaryStress(row.Raw, *ParseEntry(row.Entry))
+
+			assert.Equal(t, row.Res, strings.Join(res, "."))
+			assert.Equal(t, row.ResStress, resStress)
+			assert.Equal(t, row.ResSecondaryStress, resSecondaryStress)
+		})
+	}
+}